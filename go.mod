@@ -13,6 +13,7 @@ require (
 	github.com/jeremmfr/junosdecode v1.1.1
 	github.com/quasilyte/go-ruleguard/dsl v0.3.23
 	golang.org/x/crypto v0.55.0
+	golang.org/x/net v0.57.0
 )
 
 require (
@@ -55,7 +56,6 @@ require (
 	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
 	github.com/zclconf/go-cty v1.18.1 // indirect
 	golang.org/x/mod v0.38.0 // indirect
-	golang.org/x/net v0.57.0 // indirect
 	golang.org/x/sync v0.22.0 // indirect
 	golang.org/x/sys v0.47.0 // indirect
 	golang.org/x/text v0.41.0 // indirect
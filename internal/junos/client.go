@@ -2,7 +2,12 @@ package junos
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
 	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
 )
 
 const directoryPermission = 0o755
@@ -13,28 +18,80 @@ type Client struct {
 	junosPort                       int
 	junosUserName                   string
 	junosPassword                   string
+	junosOTP                        string
+	junosOTPFile                    string
 	junosSSHKeyPEM                  string
 	junosSSHKeyFile                 string
 	junosSSHKeyPass                 string
+	junosSSHCertPEM                 string
+	junosSSHCertFile                string
 	groupIntDel                     string
 	decodeSecrets                   bool
 	sleepShort                      int
 	sleepLock                       int
 	junosCommitConfirmed            int
 	junosCommitConfirmedWaitPercent int
+	healthProbes                    []HealthProbe
+	healthProbeFailure              *healthProbeFailure
 	sleepSSHClosed                  int
 	junosSSHCiphers                 []string
 	junosSSHTimeoutToEstab          int
 	junosSSHRetryToEstab            int
+	junosSSHHostKey                 *sshHostKeyOptions
+	junosSSHJumpHosts               []SSHJumpHost
+	junosProxy                      *url.URL
 	filePermission                  int64
 	logFileDst                      string
 	fakeCreateSetFile               string
 	fakeUpdateAlso                  bool
 	fakeDeleteAlso                  bool
+	fakeCaptureLines                func([]string) error
 	singleSession                   bool
+	configMode                      string
+	commitSynchronize               string
+	planCommitCheck                 bool
+	planJunosDiff                   bool
+	netconfRecordFile               string
+	netconfReplay                   *netconfReplay
+	configSnapshot                  *configSnapshot
 
 	sharedSession      *Session
 	mutexSharedSession sync.Mutex
+
+	sessionPool           *sessionPool
+	commitBatch           *commitBatch
+	deferredCommitConfirm *deferredCommitConfirm
+
+	commitSynchronizeDetection commitSynchronizeDetection
+
+	planPhase           *planPhase
+	planCheckedLines    []string
+	mutexPlanCheckLines sync.Mutex
+
+	devices            map[string]Device
+	deviceClients      map[string]*Client
+	mutexDeviceClients sync.Mutex
+}
+
+// SSHJumpHost: SSH server to go through to reach the Junos device.
+type SSHJumpHost struct {
+	IP          string
+	Port        int
+	UserName    string
+	Password    string
+	SSHKeyPEM   string
+	SSHKeyFile  string
+	SSHKeyPass  string
+	SSHCertPEM  string
+	SSHCertFile string
+
+	// options to verify the SSH host key of the jump host
+	HostKeys            []string
+	HostKeyFingerprints []string
+	KnownHostsFile      string
+	TrustOnFirstUse     bool
+
+	hostKey *sshHostKeyOptions
 }
 
 func NewClient(ip string) *Client {
@@ -43,9 +100,13 @@ func NewClient(ip string) *Client {
 		junosPort:                       830,
 		junosUserName:                   "netconf",
 		junosPassword:                   "",
+		junosOTP:                        "",
+		junosOTPFile:                    "",
 		junosSSHKeyPEM:                  "",
 		junosSSHKeyFile:                 "",
 		junosSSHKeyPass:                 "",
+		junosSSHCertPEM:                 "",
+		junosSSHCertFile:                "",
 		groupIntDel:                     "",
 		decodeSecrets:                   true,
 		sleepShort:                      100,
@@ -56,12 +117,16 @@ func NewClient(ip string) *Client {
 		junosSSHCiphers:                 DefaultSSHCiphers(),
 		junosSSHTimeoutToEstab:          0,
 		junosSSHRetryToEstab:            1,
+		junosSSHHostKey:                 &sshHostKeyOptions{},
 		filePermission:                  0o644,
 		logFileDst:                      "",
+		planPhase:                       &planPhase{},
 		fakeCreateSetFile:               "",
 		fakeUpdateAlso:                  false,
 		fakeDeleteAlso:                  false,
 		singleSession:                   false,
+		configMode:                      ConfigModeShared,
+		commitSynchronize:               CommitSynchronizeAuto,
 	}
 }
 
@@ -83,6 +148,21 @@ func (clt *Client) WithPassword(password string) *Client {
 	return clt
 }
 
+// WithOTP: one-time password to answer keyboard-interactive prompts which request it.
+func (clt *Client) WithOTP(otp string) *Client {
+	clt.junosOTP = otp
+
+	return clt
+}
+
+// WithOTPFile: file to read (at each authentication) the one-time password
+// to answer keyboard-interactive prompts which request it.
+func (clt *Client) WithOTPFile(otpFile string) *Client {
+	clt.junosOTPFile = otpFile
+
+	return clt
+}
+
 func (clt *Client) WithSSHKeyPEM(sshKeyPEM string) *Client {
 	clt.junosSSHKeyPEM = sshKeyPEM
 
@@ -101,6 +181,20 @@ func (clt *Client) WithSSHKeyPassphrase(sshKeyPass string) *Client {
 	return clt
 }
 
+// WithSSHCertPEM: OpenSSH user certificate (content of *-cert.pub file) signed for the SSH key.
+func (clt *Client) WithSSHCertPEM(sshCertPEM string) *Client {
+	clt.junosSSHCertPEM = sshCertPEM
+
+	return clt
+}
+
+// WithSSHCertFile: path to OpenSSH user certificate (*-cert.pub file) signed for the SSH key.
+func (clt *Client) WithSSHCertFile(sshCertFile string) *Client {
+	clt.junosSSHCertFile = sshCertFile
+
+	return clt
+}
+
 func (clt *Client) WithGroupInterfaceDelete(groupIntDel string) *Client {
 	clt.groupIntDel = groupIntDel
 
@@ -143,6 +237,27 @@ func (clt *Client) WithCommitConfirmedWaitPercent(percent int) (*Client, error)
 	return clt, nil
 }
 
+// WithCommitConfirmedDeferred: with commit confirmed, don't wait and confirm after each commit
+// but confirm once at the end of the last resource action in progress or with ConfirmCommit.
+func (clt *Client) WithCommitConfirmedDeferred() (*Client, error) {
+	if clt.junosCommitConfirmed == 0 {
+		return clt, errors.New("timeout of commit confirmed need to be set to defer the confirmation")
+	}
+	clt.deferredCommitConfirm = newDeferredCommitConfirm(clt.newSession)
+
+	return clt, nil
+}
+
+// commitConfirmedWait return the duration to wait before the confirmation of commit confirmed
+// (percentage of timeout).
+func (clt *Client) commitConfirmedWait() time.Duration {
+	return time.Duration(
+		int(
+			(time.Duration(clt.junosCommitConfirmed)*time.Minute).Microseconds(),
+		)*clt.junosCommitConfirmedWaitPercent/100,
+	) * time.Microsecond
+}
+
 func (clt *Client) WithSleepSSHClosed(sleep int) *Client {
 	clt.sleepSSHClosed = sleep
 
@@ -170,6 +285,79 @@ func (clt *Client) WithSSHRetryToEstablish(retry int) (*Client, error) {
 	return clt, nil
 }
 
+func (clt *Client) WithSSHHostKeys(keys []string) (*Client, error) {
+	for _, v := range keys {
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(v))
+		if err != nil {
+			return clt, fmt.Errorf("bad value for SSH host key %q: %w", v, err)
+		}
+		clt.junosSSHHostKey.publicKeys = append(clt.junosSSHHostKey.publicKeys, key)
+	}
+
+	return clt, nil
+}
+
+func (clt *Client) WithSSHHostKeyFingerprints(fingerprints []string) (*Client, error) {
+	for _, v := range fingerprints {
+		if err := validateFingerprint(v); err != nil {
+			return clt, fmt.Errorf("bad value for SSH host key fingerprint: %w", err)
+		}
+		clt.junosSSHHostKey.fingerprints = append(clt.junosSSHHostKey.fingerprints, v)
+	}
+
+	return clt, nil
+}
+
+func (clt *Client) WithSSHKnownHostsFile(file string) *Client {
+	clt.junosSSHHostKey.knownHostsFile = file
+
+	return clt
+}
+
+func (clt *Client) WithSSHTrustOnFirstUse() *Client {
+	clt.junosSSHHostKey.trustFirstUse = true
+
+	return clt
+}
+
+// WithSSHJumpHosts: chain of jump hosts to go through (in order) to reach the device.
+func (clt *Client) WithSSHJumpHosts(jumpHosts []SSHJumpHost) (*Client, error) {
+	for i, jumpHost := range jumpHosts {
+		jumpHosts[i].hostKey = &sshHostKeyOptions{
+			knownHostsFile: jumpHost.KnownHostsFile,
+			trustFirstUse:  jumpHost.TrustOnFirstUse,
+		}
+		for _, v := range jumpHost.HostKeys {
+			key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(v))
+			if err != nil {
+				return clt, fmt.Errorf("bad value for SSH host key %q of jump host %s: %w", v, jumpHost.IP, err)
+			}
+			jumpHosts[i].hostKey.publicKeys = append(jumpHosts[i].hostKey.publicKeys, key)
+		}
+		for _, v := range jumpHost.HostKeyFingerprints {
+			if err := validateFingerprint(v); err != nil {
+				return clt, fmt.Errorf("bad value for SSH host key fingerprint of jump host %s: %w", jumpHost.IP, err)
+			}
+			jumpHosts[i].hostKey.fingerprints = append(jumpHosts[i].hostKey.fingerprints, v)
+		}
+	}
+	clt.junosSSHJumpHosts = jumpHosts
+
+	return clt, nil
+}
+
+// WithProxy: SOCKS5 (socks5:// or socks5h://) or HTTP CONNECT (http://) proxy
+// to go through to reach the device (or the first jump host).
+func (clt *Client) WithProxy(proxyURL string) (*Client, error) {
+	u, err := validateProxyURL(proxyURL)
+	if err != nil {
+		return clt, fmt.Errorf("bad value for proxy: %w", err)
+	}
+	clt.junosProxy = u
+
+	return clt, nil
+}
+
 func (clt *Client) WithFilePermission(perm int64) (*Client, error) {
 	if perm > 0o777 || perm < 0 {
 		return clt, errors.New("bad value for file permision, must be three octal digits")
@@ -209,8 +397,63 @@ func (clt *Client) WithSingleSession() *Client {
 	return clt
 }
 
+// WithConfigMode: mode to edit the candidate configuration
+// (shared with lock, private or exclusive).
+func (clt *Client) WithConfigMode(mode string) (*Client, error) {
+	switch mode {
+	case ConfigModeShared, ConfigModePrivate, ConfigModeExclusive:
+	default:
+		return clt, fmt.Errorf("bad value %q for configuration mode", mode)
+	}
+	clt.configMode = mode
+
+	return clt, nil
+}
+
+// WithSessionPool: reuse NETCONF sessions between operations
+// with at most size sessions opened at the same time
+// and close idle sessions after idleTimeout seconds (0 to never close them).
+func (clt *Client) WithSessionPool(size, idleTimeout int) (*Client, error) {
+	if size < 1 {
+		return clt, errors.New("bad value for size of session pool, must be at least 1")
+	}
+	if idleTimeout < 0 {
+		return clt, errors.New("bad value for idle timeout of session pool, must be positive")
+	}
+	clt.sessionPool = newSessionPool(size, time.Duration(idleTimeout)*time.Second)
+
+	return clt, nil
+}
+
+// WithBatchCommit: collect the changes of resource actions to commit them together
+// in a private candidate configuration when there are no other changes
+// submitted during window milliseconds.
+func (clt *Client) WithBatchCommit(window int) (*Client, error) {
+	if window < 1 {
+		return clt, errors.New("bad value for window of batch commit, must be at least 1 millisecond")
+	}
+	clt.commitBatch = newCommitBatch(time.Duration(window)*time.Millisecond, clt.newSession)
+
+	return clt, nil
+}
+
+// WithPlanCommitCheck: check the commit of set/delete lines of resources when planning.
+func (clt *Client) WithPlanCommitCheck() *Client {
+	clt.planCommitCheck = true
+
+	return clt
+}
+
+// WithPlanJunosDiff: generate the set/delete lines of resources when planning
+// to show them in plan.
+func (clt *Client) WithPlanJunosDiff() *Client {
+	clt.planJunosDiff = true
+
+	return clt
+}
+
 func (clt *Client) FakeCreateSetFile() bool {
-	return clt.fakeCreateSetFile != ""
+	return clt.fakeCreateSetFile != "" || clt.fakeCaptureLines != nil
 }
 
 func (clt *Client) FakeUpdateAlso() bool {
@@ -221,6 +464,18 @@ func (clt *Client) FakeDeleteAlso() bool {
 	return clt.fakeDeleteAlso
 }
 
+func (clt *Client) PlanCommitCheck() bool {
+	return clt.planCommitCheck
+}
+
+func (clt *Client) PlanJunosDiff() bool {
+	return clt.planJunosDiff
+}
+
+func (clt *Client) SingleSession() bool {
+	return clt.singleSession
+}
+
 func (clt *Client) GroupInterfaceDelete() string {
 	return clt.groupIntDel
 }
@@ -0,0 +1,322 @@
+package junos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jeremmfr/go-netconf/netconf"
+)
+
+// commitBatch: collect the configuration changes of resource actions
+// to load them in a private candidate configuration and commit them together.
+//
+// The commit is done when no other change is submitted during the window.
+// If the commit fails, the actions whose lines match the path of errors are excluded
+// and receive these errors, then the commit is retried with the other actions.
+type commitBatch struct {
+	window     time.Duration
+	newSession func(context.Context) (*Session, error)
+
+	pending []*commitBatchEntry
+	timer   *time.Timer
+	mutex   sync.Mutex
+}
+
+type commitBatchEntry struct {
+	loads      []commitBatchLoad
+	logMessage string
+	result     chan commitBatchResult
+}
+
+type commitBatchLoad struct {
+	action string
+	format string
+	config string
+}
+
+type commitBatchResult struct {
+	warnings []error
+	err      error
+}
+
+func newCommitBatch(window time.Duration, newSession func(context.Context) (*Session, error)) *commitBatch {
+	return &commitBatch{
+		window:     window,
+		newSession: newSession,
+	}
+}
+
+// submit add the changes in the next commit and wait for the result of commit.
+func (batch *commitBatch) submit(
+	ctx context.Context, loads []commitBatchLoad, logMessage string,
+) (
+	[]error, error,
+) {
+	if len(loads) == 0 {
+		return nil, nil
+	}
+	entry := &commitBatchEntry{
+		loads:      loads,
+		logMessage: logMessage,
+		result:     make(chan commitBatchResult, 1),
+	}
+
+	batch.mutex.Lock()
+	batch.pending = append(batch.pending, entry)
+	if batch.timer == nil {
+		batch.timer = time.AfterFunc(batch.window, batch.run)
+	} else {
+		batch.timer.Reset(batch.window)
+	}
+	batch.mutex.Unlock()
+
+	select {
+	case result := <-entry.result:
+		return result.warnings, result.err
+	case <-ctx.Done():
+		return nil, errors.New("waiting for the batch commit aborted, the changes may be committed anyway")
+	}
+}
+
+// run commit the pending changes.
+func (batch *commitBatch) run() {
+	batch.mutex.Lock()
+	entries := batch.pending
+	batch.pending = nil
+	batch.timer = nil
+	batch.mutex.Unlock()
+
+	if len(entries) == 0 {
+		return
+	}
+
+	batch.commit(context.Background(), entries)
+}
+
+func (batch *commitBatch) commit(ctx context.Context, entries []*commitBatchEntry) {
+	sess, err := batch.newSession(ctx)
+	if err != nil {
+		finishCommitBatchEntries(entries, nil, fmt.Errorf("starting session for batch commit: %w", err))
+
+		return
+	}
+	// the session of batch commits directly
+	sess.commitBatch = nil
+	defer sess.Close()
+
+	if err := sess.netconfExecRPC(rpcOpenConfigurationPrivate, "open-configuration(private)"); err != nil {
+		finishCommitBatchEntries(entries, nil, fmt.Errorf("opening private candidate configuration: %w", err))
+
+		return
+	}
+	defer func() {
+		if err := sess.netconfExecRPC(rpcCloseConfiguration, "close-configuration"); err != nil {
+			sess.logFile(fmt.Sprintf("[commitBatch] close-configuration err: %q", err))
+		}
+	}()
+
+	for len(entries) > 0 {
+		loadFailed, loaded := sess.loadCommitBatchEntries(entries)
+		if len(loadFailed) > 0 {
+			for entry, err := range loadFailed {
+				entry.result <- commitBatchResult{
+					err: fmt.Errorf("batch commit failed to load changes of this resource action (not committed): %w", err),
+				}
+			}
+			if len(loaded) == 0 {
+				return
+			}
+			sess.logFile(fmt.Sprintf(
+				"[commitBatch] reload changes without %d resource actions in error", len(loadFailed),
+			))
+			if err := sess.netconfExecRPC(rpcLoadConfigRollback0, "load-configuration(rollback 0)"); err != nil {
+				finishCommitBatchEntries(loaded, nil, fmt.Errorf(
+					"discarding changes in private candidate configuration after a load error"+
+						" of other resource actions: %w", err,
+				))
+
+				return
+			}
+			entries = loaded
+
+			continue
+		}
+		warns, err := sess.CommitConf(ctx, fmt.Sprintf("batch commit of %d resource actions", len(entries)))
+		if err == nil {
+			finishCommitBatchEntries(entries, warns, nil)
+
+			return
+		}
+
+		failed, others := attributeCommitBatchError(entries, err)
+		if len(failed) == 0 || len(others) == 0 {
+			finishCommitBatchEntries(entries, warns, err)
+
+			return
+		}
+		for entry, errs := range failed {
+			entry.result <- commitBatchResult{
+				warnings: warns,
+				err: errors.New(
+					"batch commit failed on changes of this resource action (not committed):\n" +
+						strings.Join(errs, "\n"),
+				),
+			}
+		}
+		sess.logFile(fmt.Sprintf(
+			"[commitBatch] retry commit without %d resource actions in error", len(failed),
+		))
+		if err := sess.netconfExecRPC(rpcLoadConfigRollback0, "load-configuration(rollback 0)"); err != nil {
+			finishCommitBatchEntries(others, nil, fmt.Errorf(
+				"discarding changes in private candidate configuration after a commit error"+
+					" of other resource actions: %w", err,
+			))
+
+			return
+		}
+		entries = others
+	}
+}
+
+// loadCommitBatchEntries load the changes of entries in candidate configuration.
+//
+// Return the load errors of each entry in error and the other entries.
+func (sess *Session) loadCommitBatchEntries(
+	entries []*commitBatchEntry,
+) (
+	map[*commitBatchEntry]error, []*commitBatchEntry,
+) {
+	failed := make(map[*commitBatchEntry]error)
+	loaded := make([]*commitBatchEntry, 0, len(entries))
+	for _, entry := range entries {
+		if err := sess.loadCommitBatchEntry(entry); err != nil {
+			failed[entry] = err
+
+			continue
+		}
+		loaded = append(loaded, entry)
+	}
+
+	return failed, loaded
+}
+
+// loadCommitBatchEntry load the changes of entry in candidate configuration
+// and return the errors of load (the warnings are only logged).
+func (sess *Session) loadCommitBatchEntry(entry *commitBatchEntry) error {
+	errs := make([]error, 0)
+	for _, load := range entry.loads {
+		reply, err := sess.netconfConfigLoadReply(load.action, load.format, load.config)
+		if err != nil {
+			sess.logFile(fmt.Sprintf("[commitBatch] load for %q err: %q", entry.logMessage, err))
+			errs = append(errs, err)
+
+			continue
+		}
+		for _, m := range reply.Errors {
+			sess.logFile(fmt.Sprintf("[commitBatch] load for %q %s: %q", entry.logMessage, m.Severity, m.Message))
+			if m.Severity == errorSeverity {
+				errs = append(errs, &m)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func finishCommitBatchEntries(entries []*commitBatchEntry, warnings []error, err error) {
+	for _, entry := range entries {
+		entry.result <- commitBatchResult{warnings: warnings, err: err}
+	}
+}
+
+// attributeCommitBatchError find the entries with set/delete lines under (or above)
+// the configuration path of commit errors.
+//
+// Return the errors of each attributed entry and the other entries.
+func attributeCommitBatchError(
+	entries []*commitBatchEntry, err error,
+) (
+	map[*commitBatchEntry][]string, []*commitBatchEntry,
+) {
+	var rpcErrors []netconf.RPCError
+	var commitErr *CommitError
+	var rpcErr *netconf.RPCError
+	switch {
+	case errors.As(err, &commitErr):
+		rpcErrors = commitErr.RPCErrors
+	case errors.As(err, &rpcErr):
+		rpcErrors = []netconf.RPCError{*rpcErr}
+	default:
+		return nil, entries
+	}
+
+	failed := make(map[*commitBatchEntry][]string)
+	for _, m := range rpcErrors {
+		errPath := commitErrorPathWords(m.Path)
+		if len(errPath) == 0 {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.hasLineMatchingPath(errPath) {
+				failed[entry] = append(failed[entry], m.Error())
+			}
+		}
+	}
+
+	others := make([]*commitBatchEntry, 0, len(entries))
+	for _, entry := range entries {
+		if _, ok := failed[entry]; !ok {
+			others = append(others, entry)
+		}
+	}
+
+	return failed, others
+}
+
+// commitErrorPathWords return the words of path in commit error
+// without the "edit" keyword ("[edit interfaces ge-0/0/0]" -> ["interfaces", "ge-0/0/0"]).
+func commitErrorPathWords(errPath string) []string {
+	words := strings.Fields(strings.Trim(strings.TrimSpace(errPath), "[]"))
+	if len(words) > 0 && words[0] == "edit" {
+		words = words[1:]
+	}
+
+	return words
+}
+
+func (entry *commitBatchEntry) hasLineMatchingPath(errPath []string) bool {
+	for _, load := range entry.loads {
+		if load.action != LoadConfigActionSet {
+			continue
+		}
+		for line := range strings.Lines(load.config) {
+			words := strings.Fields(line)
+			if len(words) < 2 {
+				continue
+			}
+			words = words[1:] // remove set/delete keyword
+			if hasPrefixWords(words, errPath) || hasPrefixWords(errPath, words) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func hasPrefixWords(words, prefix []string) bool {
+	if len(prefix) > len(words) {
+		return false
+	}
+	for i, v := range prefix {
+		if strings.Trim(words[i], `"`) != strings.Trim(v, `"`) {
+			return false
+		}
+	}
+
+	return true
+}
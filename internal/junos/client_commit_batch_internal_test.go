@@ -0,0 +1,205 @@
+package junos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jeremmfr/go-netconf/netconf"
+)
+
+func TestAttributeCommitBatchError(t *testing.T) {
+	t.Parallel()
+
+	entryInterface := &commitBatchEntry{
+		loads: []commitBatchLoad{{
+			action: LoadConfigActionSet,
+			config: "set interfaces ge-0/0/3 unit 0 family inet address 192.0.2.1/24\n" +
+				"set interfaces ge-0/0/3 description \"uplink sw1\"",
+		}},
+	}
+	entryVlan := &commitBatchEntry{
+		loads: []commitBatchLoad{{
+			action: LoadConfigActionSet,
+			config: "set vlans vlan10 vlan-id 10",
+		}},
+	}
+	entryDelete := &commitBatchEntry{
+		loads: []commitBatchLoad{{
+			action: LoadConfigActionSet,
+			config: "delete policy-options prefix-list pl1",
+		}},
+	}
+	entries := []*commitBatchEntry{entryInterface, entryVlan, entryDelete}
+
+	type testCase struct {
+		err          error
+		expectFailed []*commitBatchEntry
+	}
+	tests := map[string]testCase{
+		"error_under_lines": {
+			err: &CommitError{RPCErrors: []netconf.RPCError{{
+				Severity: errorSeverity,
+				Path:     "[edit interfaces ge-0/0/3 unit 0 family inet]",
+				Message:  "address overlap",
+			}}},
+			expectFailed: []*commitBatchEntry{entryInterface},
+		},
+		"error_above_delete_line": {
+			err: fmt.Errorf("executing netconf commit: %w", &netconf.RPCError{
+				Severity: errorSeverity,
+				Path:     "\n[edit policy-options prefix-list pl1 192.0.2.0/24]\n",
+				Message:  "referenced",
+			}),
+			expectFailed: []*commitBatchEntry{entryDelete},
+		},
+		"errors_multiple": {
+			err: &CommitError{RPCErrors: []netconf.RPCError{
+				{Severity: errorSeverity, Path: "[edit vlans vlan10]", Message: "bad vlan"},
+				{Severity: errorSeverity, Path: "[edit interfaces ge-0/0/3]", Message: "bad interface"},
+			}},
+			expectFailed: []*commitBatchEntry{entryInterface, entryVlan},
+		},
+		"error_without_path": {
+			err: &CommitError{RPCErrors: []netconf.RPCError{{
+				Severity: errorSeverity,
+				Message:  "configuration check-out failed",
+			}}},
+		},
+		"error_not_netconf": {
+			err: errors.New("EOF"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			failed, others := attributeCommitBatchError(entries, test.err)
+			if len(failed) != len(test.expectFailed) {
+				t.Fatalf("got %d entries in error, expected %d", len(failed), len(test.expectFailed))
+			}
+			for _, entry := range test.expectFailed {
+				if _, ok := failed[entry]; !ok {
+					t.Errorf("expected entry with %q in error", entry.loads[0].config)
+				}
+			}
+			if len(failed)+len(others) != len(entries) {
+				t.Errorf("got %d other entries, expected %d", len(others), len(entries)-len(failed))
+			}
+		})
+	}
+}
+
+func TestSessionCommitBatchLoads(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	sess := &Session{
+		logFile:     func(string) {},
+		commitBatch: newCommitBatch(time.Millisecond, nil),
+	}
+
+	if err := sess.ConfigLock(ctx); err != nil {
+		t.Fatalf("got unexpected error on lock: %s", err)
+	}
+	if err := sess.ConfigSet(ctx, []string{"set vlans vlan10 vlan-id 10"}); err != nil {
+		t.Fatalf("got unexpected error on set: %s", err)
+	}
+	if err := sess.ConfigLoad(ctx, LoadConfigActionMerge, ConfigFormatText, "vlans { vlan20; }"); err != nil {
+		t.Fatalf("got unexpected error on load: %s", err)
+	}
+	if err := sess.ConfigLoad(ctx, LoadConfigActionOverride, ConfigFormatText, "vlans { vlan20; }"); err == nil {
+		t.Errorf("expected error on load with override action but got none")
+	}
+	if len(sess.commitBatchLoads) != 2 {
+		t.Errorf("got %d loads for batch commit, expected 2", len(sess.commitBatchLoads))
+	}
+	if errs := sess.ConfigUnlock(ctx); len(errs) > 0 {
+		t.Fatalf("got unexpected errors on unlock: %v", errs)
+	}
+	if len(sess.commitBatchLoads) != 0 {
+		t.Errorf("loads for batch commit not dropped on unlock")
+	}
+	// nothing to submit
+	if _, err := sess.CommitConf(ctx, "test"); err != nil {
+		t.Errorf("got unexpected error on commit without changes: %s", err)
+	}
+}
+
+func TestSessionConfigDiscardCommitBatch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	sess := &Session{
+		logFile:     func(string) {},
+		commitBatch: newCommitBatch(time.Millisecond, nil),
+	}
+
+	if err := sess.ConfigSet(ctx, []string{"set vlans vlan10 vlan-id 10", "delete vlans vlan20"}); err != nil {
+		t.Fatalf("got unexpected error on set: %s", err)
+	}
+	changes, err := sess.ConfigDiscard(ctx)
+	if err != nil {
+		t.Fatalf("got unexpected error on discard: %s", err)
+	}
+	if expected := "set vlans vlan10 vlan-id 10\ndelete vlans vlan20"; changes != expected {
+		t.Errorf("got discarded changes %q, expected %q", changes, expected)
+	}
+	if len(sess.commitBatchLoads) != 0 {
+		t.Errorf("loads for batch commit not dropped on discard")
+	}
+}
+
+func TestCommitBatchLoadError(t *testing.T) {
+	t.Parallel()
+
+	srv, clt := newSimulatorClient(t)
+	clt, err := clt.WithBatchCommit(100)
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+
+	ctx := context.Background()
+	loads := map[string]string{
+		"vlan10": "set vlans vlan10 vlan-id 10",
+		"bad":    "set vlans vlan20 vlan-id 20\nbad vlans vlan20",
+		"vlan30": "set vlans vlan30 vlan-id 30",
+	}
+	errs := make(map[string]error)
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	for name, config := range loads {
+		wg.Go(func() {
+			_, err := clt.commitBatch.submit(ctx, []commitBatchLoad{{
+				action: LoadConfigActionSet,
+				format: ConfigFormatText,
+				config: config,
+			}}, name)
+			mutex.Lock()
+			errs[name] = err
+			mutex.Unlock()
+		})
+	}
+	wg.Wait()
+
+	if errs["bad"] == nil {
+		t.Errorf("expected error for resource action with load error but got none")
+	}
+	if errs["vlan10"] != nil || errs["vlan30"] != nil {
+		t.Errorf("got unexpected errors for other resource actions: %v", errs)
+	}
+	expectConfig := []string{"set vlans vlan10 vlan-id 10", "set vlans vlan30 vlan-id 30"}
+	config := srv.Config()
+	slices.Sort(config)
+	if !slices.Equal(config, expectConfig) {
+		t.Errorf("got unexpected config %q, expected %q", config, expectConfig)
+	}
+	if commits := srv.Commits(); len(commits) != 1 {
+		t.Errorf("got %d commits, expected 1", len(commits))
+	}
+}
@@ -0,0 +1,146 @@
+package junos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// deferredCommitConfirm: confirmation of commits with 'confirmed' option done once
+// at the end of the last resource action in progress (from the lock of the candidate configuration
+// to the commit) or on demand, instead of after a wait for each commit.
+//
+// There is no signal of the end of apply, so the end of the last resource action in progress
+// can happen between two waves of resources (a resource waiting for its dependencies).
+//
+// If the confirmation is never done (like when it fails or Terraform is killed),
+// the device rolls back the configuration after the timeout of the last commit.
+type deferredCommitConfirm struct {
+	newSession func(context.Context) (*Session, error)
+
+	inProgress int
+	pending    bool
+	mutex      sync.Mutex
+}
+
+func newDeferredCommitConfirm(newSession func(context.Context) (*Session, error)) *deferredCommitConfirm {
+	return &deferredCommitConfirm{
+		newSession: newSession,
+	}
+}
+
+// begin record a resource action in progress which can commit.
+func (confirm *deferredCommitConfirm) begin() {
+	confirm.mutex.Lock()
+	defer confirm.mutex.Unlock()
+
+	confirm.inProgress++
+}
+
+// end record the end of a resource action (begun or not), with a commit or not,
+// and confirm the pending commits with the session of action
+// if there is no other resource action in progress.
+//
+// Return a warning when the commits are left unconfirmed for the other resource actions in progress.
+func (confirm *deferredCommitConfirm) end(
+	ctx context.Context, sess *Session, begun, committed bool,
+) (
+	[]error, error,
+) {
+	confirm.mutex.Lock()
+	defer confirm.mutex.Unlock()
+
+	if begun {
+		confirm.inProgress--
+	}
+	if committed {
+		confirm.pending = true
+	}
+	if !confirm.pending {
+		return nil, nil
+	}
+	if confirm.inProgress > 0 {
+		sess.logFile(fmt.Sprintf(
+			"[deferredCommitConfirm] confirmation left to %d resource actions in progress", confirm.inProgress,
+		))
+		if !committed {
+			return nil, nil
+		}
+
+		return []error{fmt.Errorf(
+			"commit with 'confirmed' option not confirmed yet, "+
+				"the confirmation is left to the end of %d other resource actions in progress",
+			confirm.inProgress,
+		)}, nil
+	}
+
+	return confirm.confirmWithSession(ctx, sess)
+}
+
+// confirm run the health probes and send the confirmation of pending commits with a new session
+// (or always with force, like when the commits are done by another process).
+func (confirm *deferredCommitConfirm) confirm(ctx context.Context, force bool) ([]error, error) {
+	confirm.mutex.Lock()
+	defer confirm.mutex.Unlock()
+
+	if !confirm.pending && !force {
+		return nil, nil
+	}
+
+	sess, err := confirm.newSession(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("starting session to confirm commit: %w", err)
+	}
+	defer sess.Close()
+
+	return confirm.confirmWithSession(ctx, sess)
+}
+
+// confirmWithSession run the health probes and send the confirmation of pending commits.
+//
+// Need to be called with the mutex locked.
+func (confirm *deferredCommitConfirm) confirmWithSession(ctx context.Context, sess *Session) ([]error, error) {
+	if err := sess.runHealthProbes(ctx); err != nil {
+		// the device rolls back the configuration, nothing more to confirm
+		confirm.pending = false
+		sess.logFile(fmt.Sprintf("[deferredCommitConfirm] health probes err: %q", err))
+
+		return nil, sess.errNotConfirmed(err)
+	}
+	sess.logFile("[deferredCommitConfirm] confirm commit")
+	warnings, err := sess.netconfCommitConfirm()
+	if err != nil {
+		sess.logFile(fmt.Sprintf("[deferredCommitConfirm] confirm commit err: %q", err))
+
+		return warnings, err
+	}
+	confirm.pending = false
+
+	return warnings, nil
+}
+
+// ConfirmCommit run the health probes and confirm the commits with 'confirmed' option on device.
+//
+// With deferred confirmation, the pending confirmation is done now.
+func (clt *Client) ConfirmCommit(ctx context.Context) ([]error, error) {
+	if clt.deferredCommitConfirm != nil {
+		return clt.deferredCommitConfirm.confirm(ctx, true)
+	}
+	if clt.FakeCreateSetFile() {
+		return nil, errors.New("confirming commit is not possible with fake options")
+	}
+
+	sess, err := clt.StartNewSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.Close()
+
+	if err := sess.runHealthProbes(ctx); err != nil {
+		return nil, sess.errNotConfirmed(err)
+	}
+	sess.logFile("[ConfirmCommit] confirm commit")
+
+	return sess.netconfCommitConfirm()
+}
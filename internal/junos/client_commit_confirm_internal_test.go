@@ -0,0 +1,107 @@
+package junos
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDeferredCommitConfirm(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	var sessions atomic.Int32
+	confirm := newDeferredCommitConfirm(func(context.Context) (*Session, error) {
+		sessions.Add(1)
+
+		return nil, errors.New("no device")
+	})
+
+	if _, err := confirm.confirm(ctx, false); err != nil {
+		t.Fatalf("got unexpected error without pending confirmation: %s", err)
+	}
+	if v := sessions.Load(); v != 0 {
+		t.Fatalf("got %d sessions started without pending confirmation, expected 0", v)
+	}
+
+	confirm.pending = true
+	if _, err := confirm.confirm(ctx, false); err == nil {
+		t.Errorf("expected error on confirmation but got none")
+	}
+	if v := sessions.Load(); v != 1 {
+		t.Errorf("got %d sessions started to confirm, expected 1", v)
+	}
+	if !confirm.pending {
+		t.Errorf("expected confirmation still pending after error")
+	}
+
+	if _, err := confirm.confirm(ctx, true); err == nil {
+		t.Errorf("expected error on forced confirmation but got none")
+	}
+	if v := sessions.Load(); v != 2 {
+		t.Errorf("got %d sessions started to confirm, expected 2", v)
+	}
+}
+
+func TestDeferredCommitConfirmLastResourceAction(t *testing.T) {
+	t.Parallel()
+
+	srv, clt := newSimulatorClient(t)
+	clt, err := clt.WithCommitConfirmed(5)
+	if err == nil {
+		clt, err = clt.WithCommitConfirmedDeferred()
+	}
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+
+	ctx := context.Background()
+	sessCommit, err := clt.StartNewSession(ctx)
+	if err != nil {
+		t.Fatalf("starting session: %s", err)
+	}
+	defer sessCommit.Close()
+	sessOther, err := clt.StartNewSession(ctx)
+	if err != nil {
+		t.Fatalf("starting session: %s", err)
+	}
+	defer sessOther.Close()
+
+	if err := sessCommit.ConfigLock(ctx); err != nil {
+		t.Fatalf("got unexpected error on lock: %s", err)
+	}
+	if err := sessCommit.ConfigSet(ctx, []string{"set vlans vlan10 vlan-id 10"}); err != nil {
+		t.Fatalf("got unexpected error on set: %s", err)
+	}
+	// other resource action waiting for the lock
+	sessOther.beginDeferredCommitConfirm()
+
+	warns, err := sessCommit.CommitConf(ctx, "test")
+	if err != nil {
+		t.Fatalf("got unexpected error on commit: %s", err)
+	}
+	if len(warns) != 1 {
+		t.Errorf("got warnings %v, expected a warning for the commit left unconfirmed", warns)
+	}
+	if errs := sessCommit.ConfigUnlock(ctx); len(errs) > 0 {
+		t.Fatalf("got unexpected errors on unlock: %v", errs)
+	}
+	if !clt.deferredCommitConfirm.pending {
+		t.Fatalf("expected confirmation pending with another resource action in progress")
+	}
+
+	if err := sessOther.ConfigLock(ctx); err != nil {
+		t.Fatalf("got unexpected error on lock: %s", err)
+	}
+	// the last resource action in progress ends without commit
+	if errs := sessOther.ConfigUnlock(ctx); len(errs) > 0 {
+		t.Fatalf("got unexpected errors on unlock: %v", errs)
+	}
+	if clt.deferredCommitConfirm.pending || clt.deferredCommitConfirm.inProgress != 0 {
+		t.Errorf("expected commit confirmed at the end of the last resource action")
+	}
+	if v := len(srv.Commits()); v != 1 {
+		t.Errorf("got %d commits, expected 1", v)
+	}
+}
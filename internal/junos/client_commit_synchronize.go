@@ -0,0 +1,105 @@
+package junos
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sync"
+)
+
+// commitSynchronizeDetection: cache of detection of chassis cluster or redundant Routing Engines on device
+// to commit with synchronize option in auto mode.
+type commitSynchronizeDetection struct {
+	detected *bool
+	mutex    sync.Mutex
+}
+
+// WithCommitSynchronize: commit with synchronize option
+// to commit on all Routing Engines (or all nodes of chassis cluster)
+// always, never or only when a chassis cluster or redundant Routing Engines are detected on device (auto).
+func (clt *Client) WithCommitSynchronize(mode string) (*Client, error) {
+	switch mode {
+	case CommitSynchronizeAuto, CommitSynchronizeAlways, CommitSynchronizeNever:
+	default:
+		return clt, fmt.Errorf("bad value %q for commit synchronize mode", mode)
+	}
+	clt.commitSynchronize = mode
+
+	return clt, nil
+}
+
+// commitSynchronizeSession return true if the commits need the synchronize option on device of session.
+func (clt *Client) commitSynchronizeSession(sess *Session) bool {
+	switch clt.commitSynchronize {
+	case CommitSynchronizeAlways:
+		return true
+	case CommitSynchronizeNever:
+		return false
+	}
+	if sess.SystemInformation.ClusterNode != nil {
+		return true
+	}
+
+	clt.commitSynchronizeDetection.mutex.Lock()
+	defer clt.commitSynchronizeDetection.mutex.Unlock()
+	if clt.commitSynchronizeDetection.detected != nil {
+		return *clt.commitSynchronizeDetection.detected
+	}
+	reply, err := sess.netconfCommandXML(rpcGetRouteEngineInformation)
+	if err != nil {
+		// not cached to retry detection with the next session
+		sess.logFile(fmt.Sprintf("[commitSynchronizeSession] get-route-engine-information err: %q", err))
+
+		return false
+	}
+	detected, err := routeEngineRedundancy(reply)
+	if err != nil {
+		sess.logFile(fmt.Sprintf("[commitSynchronizeSession] %s", err))
+
+		return false
+	}
+	if detected {
+		sess.logFile("[commitSynchronizeSession] redundant Routing Engines detected, commit with synchronize")
+	}
+	clt.commitSynchronizeDetection.detected = &detected
+
+	return detected
+}
+
+// routeEngineRedundancy return true if there are multiple Routing Engines
+// in reply of get-route-engine-information.
+func routeEngineRedundancy(reply string) (bool, error) {
+	var root xmlNode
+	if err := xml.Unmarshal([]byte("<reply>"+reply+"</reply>"), &root); err != nil {
+		return false, fmt.Errorf("unmarshaling xml reply %q of get-route-engine-information: %w", reply, err)
+	}
+	steps, err := parseXPath("//route-engine")
+	if err != nil {
+		return false, err
+	}
+
+	return len(evalXPath(&root, steps)) > 1, nil
+}
+
+// logCommitResultsRoutingEngines log the result of commit for each Routing Engine (or node of chassis cluster)
+// and return a warning for each one without success.
+func (sess *Session) logCommitResultsRoutingEngines(
+	routingEngines []commitResultsRoutingEngine, commitType string,
+) (
+	warnings []error,
+) {
+	for _, re := range routingEngines {
+		switch {
+		case re.CommitSuccess != nil:
+			sess.logFile(fmt.Sprintf("[%s] %s: commit success", commitType, re.Name))
+		case re.CommitCheckSuccess != nil:
+			sess.logFile(fmt.Sprintf("[%s] %s: commit check success", commitType, re.Name))
+		case len(re.Errors) == 0:
+			sess.logFile(fmt.Sprintf("[%s] %s: no success", commitType, re.Name))
+			warnings = append(warnings, fmt.Errorf("no success of %s reported by %s", commitType, re.Name))
+		default:
+			sess.logFile(fmt.Sprintf("[%s] %s: %d error(s)", commitType, re.Name, len(re.Errors)))
+		}
+	}
+
+	return warnings
+}
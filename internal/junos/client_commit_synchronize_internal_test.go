@@ -0,0 +1,96 @@
+package junos
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jeremmfr/go-netconf/netconf"
+)
+
+func TestRouteEngineRedundancy(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		reply  string
+		expect bool
+	}
+	tests := map[string]testCase{
+		"single": {
+			reply: "<route-engine-information><route-engine>" +
+				"<slot>0</slot><mastership-state>master</mastership-state>" +
+				"</route-engine></route-engine-information>",
+		},
+		"dual": {
+			reply: "<route-engine-information>" +
+				"<route-engine><slot>0</slot><mastership-state>master</mastership-state></route-engine>" +
+				"<route-engine><slot>1</slot><mastership-state>backup</mastership-state></route-engine>" +
+				"</route-engine-information>",
+			expect: true,
+		},
+		"multi_routing_engine": {
+			reply: "<multi-routing-engine-results>" +
+				"<multi-routing-engine-item><re-name>node0</re-name><route-engine-information>" +
+				"<route-engine><slot>0</slot></route-engine></route-engine-information></multi-routing-engine-item>" +
+				"<multi-routing-engine-item><re-name>node1</re-name><route-engine-information>" +
+				"<route-engine><slot>0</slot></route-engine></route-engine-information></multi-routing-engine-item>" +
+				"</multi-routing-engine-results>",
+			expect: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			detected, err := routeEngineRedundancy(test.reply)
+			if err != nil {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+			if detected != test.expect {
+				t.Errorf("got %t, expected %t", detected, test.expect)
+			}
+		})
+	}
+}
+
+func TestSessionReadNetconfCommitReplyRoutingEngines(t *testing.T) {
+	t.Parallel()
+
+	sess := &Session{logFile: func(string) {}}
+
+	warnings, err := sess.readNetconfCommitReply(&netconf.RPCReply{
+		Data: "<commit-results>" +
+			"<routing-engine><name>re0</name><commit-success/></routing-engine>" +
+			"<routing-engine><name>re1</name><commit-success/></routing-engine>" +
+			"</commit-results>",
+	}, "commit-configuration(synchronize)")
+	if err != nil {
+		t.Errorf("got unexpected error: %s", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("got unexpected warnings: %v", warnings)
+	}
+
+	warnings, err = sess.readNetconfCommitReply(&netconf.RPCReply{
+		Data: "<commit-results>" +
+			"<routing-engine><name>node0</name><commit-success/></routing-engine>" +
+			"<routing-engine><name>node1</name>" +
+			"<rpc-error><error-severity>error</error-severity>" +
+			"<error-message>\nconfiguration check-out failed\n</error-message></rpc-error>" +
+			"</routing-engine>" +
+			"<routing-engine><name>node2</name></routing-engine>" +
+			"</commit-results>",
+	}, "commit-configuration(synchronize)")
+	var commitErr *CommitError
+	if !errors.As(err, &commitErr) {
+		t.Fatalf("got error %v, expected a commit error", err)
+	}
+	if len(commitErr.RPCErrors) != 1 ||
+		commitErr.RPCErrors[0].Message != "node1: configuration check-out failed" {
+		t.Errorf("got unexpected commit errors: %v", commitErr.RPCErrors)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0].Error(), "node2") {
+		t.Errorf("got unexpected warnings: %v", warnings)
+	}
+}
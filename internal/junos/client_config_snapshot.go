@@ -0,0 +1,114 @@
+package junos
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/utils"
+)
+
+var configSnapshotShowRegexp = regexp.MustCompile(
+	`^` + strings.TrimSpace(CmdShowConfig) + `(?: (.*?))?\s*\|\s*display set( relative)?$`,
+)
+
+// configSnapshot: the committed configuration of a device read once
+// to answer the show configuration commands of the sessions without connection to the device.
+type configSnapshot struct {
+	systemInformation rpcSystemInformation
+	lines             []string
+}
+
+// NewConfigSnapshotClient return a client without connection to the device
+// whose sessions read the configuration in config (the committed configuration in set format
+// read by sess) instead of sending a new command to the device.
+//
+// Only the `show configuration <path> | display set [relative]` commands
+// and the read of configuration in set format are available with these sessions.
+func (clt *Client) NewConfigSnapshotClient(sess *Session, config string) *Client {
+	snapshot := &configSnapshot{
+		systemInformation: sess.SystemInformation,
+		lines:             make([]string, 0),
+	}
+	for line := range strings.SplitSeq(config, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "set ") || strings.HasPrefix(line, "deactivate ") {
+			snapshot.lines = append(snapshot.lines, line)
+		}
+	}
+
+	return &Client{
+		junosPort:       clt.junosPort,
+		junosUserName:   clt.junosUserName,
+		groupIntDel:     clt.groupIntDel,
+		decodeSecrets:   clt.decodeSecrets,
+		junosSSHHostKey: &sshHostKeyOptions{},
+		filePermission:  clt.filePermission,
+		logFileDst:      clt.logFileDst,
+		configMode:      clt.configMode,
+		configSnapshot:  snapshot,
+	}
+}
+
+func (clt *Client) newConfigSnapshotSession() *Session {
+	sess := &Session{
+		SystemInformation: clt.configSnapshot.systemInformation,
+		localAddress:      "snapshot",
+		remoteAddress:     clt.junosIP,
+		decodeSecrets:     clt.decodeSecrets,
+		configMode:        clt.configMode,
+		configSnapshot:    clt.configSnapshot,
+	}
+	sess.logFile = func(message string) {
+		clt.logFile("[snapshot]" + message)
+	}
+
+	return sess
+}
+
+// command return the output of a show configuration command like the device.
+func (snapshot *configSnapshot) command(cmd string) (string, error) {
+	match := configSnapshotShowRegexp.FindStringSubmatch(strings.TrimSpace(cmd))
+	if match == nil {
+		return "", fmt.Errorf("command %q not available with the configuration snapshot", cmd)
+	}
+	path := strings.Join(utils.ConfigLineWords(match[1]), " ")
+	relative := match[2] != ""
+
+	output := make([]string, 0)
+	for _, line := range snapshot.lines {
+		verb, statement, _ := strings.Cut(line, " ")
+		statement = strings.Join(utils.ConfigLineWords(statement), " ")
+		switch {
+		case path == "":
+			output = append(output, line)
+		case statement == path:
+			if relative {
+				output = append(output, verb)
+			} else {
+				output = append(output, line)
+			}
+		case strings.HasPrefix(statement, path+" "):
+			if relative {
+				output = append(output, verb+" "+strings.TrimPrefix(statement, path+" "))
+			} else {
+				output = append(output, line)
+			}
+		}
+	}
+	if len(output) == 0 {
+		return EmptyW, nil
+	}
+
+	return XMLStartTagConfigOut + "\n" + strings.Join(output, "\n") + "\n" + XMLEndTagConfigOut, nil
+}
+
+// configGet return the configuration in set format.
+func (snapshot *configSnapshot) configGet(format string) (string, error) {
+	if format != ConfigFormatSet {
+		return "", errors.New("only the set format is available with the configuration snapshot")
+	}
+
+	return strings.Join(snapshot.lines, "\n") + "\n", nil
+}
@@ -0,0 +1,81 @@
+package junos
+
+import (
+	"context"
+	"testing"
+)
+
+func TestConfigSnapshotClient(t *testing.T) {
+	t.Parallel()
+
+	sess := &Session{SystemInformation: rpcSystemInformation{HardwareModel: "srx300"}}
+	client := NewClient("192.0.2.1").NewConfigSnapshotClient(sess,
+		"## Last commit: 2026-10-17 10:00:00 UTC by netconf\n"+
+			"set version 23.4R1\n"+
+			"set vlans vlan10 vlan-id 10\n"+
+			"set vlans vlan10 description \"vlan 10\"\n"+
+			"deactivate vlans vlan10 description\n"+
+			"set vlans vlan100 vlan-id 100\n",
+	)
+
+	junSess, err := client.StartNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("got unexpected error on start session: %s", err)
+	}
+	t.Cleanup(junSess.Close)
+	if !junSess.CheckCompatibilitySecurity() {
+		t.Errorf("expected the system information of the session used to read the configuration")
+	}
+
+	tests := map[string]struct {
+		cmd    string
+		expect string
+	}{
+		"Path": {
+			cmd: CmdShowConfig + "vlans vlan10" + PipeDisplaySet,
+			expect: XMLStartTagConfigOut + "\n" +
+				"set vlans vlan10 vlan-id 10\n" +
+				"set vlans vlan10 description \"vlan 10\"\n" +
+				"deactivate vlans vlan10 description\n" +
+				XMLEndTagConfigOut,
+		},
+		"PathRelative": {
+			cmd: CmdShowConfig + "vlans \"vlan100\"" + PipeDisplaySetRelative,
+			expect: XMLStartTagConfigOut + "\n" +
+				"set vlan-id 100\n" +
+				XMLEndTagConfigOut,
+		},
+		"Missing": {
+			cmd:    CmdShowConfig + "vlans vlan20" + PipeDisplaySetRelative,
+			expect: EmptyW,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			read, err := junSess.Command(context.Background(), test.cmd)
+			if err != nil {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+			if read != test.expect {
+				t.Errorf("got %q, expected %q", read, test.expect)
+			}
+		})
+	}
+
+	if _, err := junSess.Command(context.Background(), "show version"); err == nil {
+		t.Errorf("expected error on a command other than show configuration")
+	}
+	config, err := junSess.ConfigGet(context.Background(), ConfigFormatSet)
+	if err != nil {
+		t.Fatalf("got unexpected error on config get: %s", err)
+	}
+	if expect := "set version 23.4R1\n" +
+		"set vlans vlan10 vlan-id 10\n" +
+		"set vlans vlan10 description \"vlan 10\"\n" +
+		"deactivate vlans vlan10 description\n" +
+		"set vlans vlan100 vlan-id 100\n"; config != expect {
+		t.Errorf("got config %q, expected %q", config, expect)
+	}
+}
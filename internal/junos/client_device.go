@@ -0,0 +1,190 @@
+package junos
+
+import (
+	"fmt"
+	"slices"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Device: connection options of a named Junos device reachable with the same client options.
+//
+// Empty options are inherited from the client.
+type Device struct {
+	IP          string
+	Port        int
+	UserName    string
+	Password    string
+	SSHKeyPEM   string
+	SSHKeyFile  string
+	SSHKeyPass  string
+	SSHCertPEM  string
+	SSHCertFile string
+
+	// keys and fingerprints trusted for the SSH host key of the device
+	// (instead of those of the client)
+	HostKeys            []string
+	HostKeyFingerprints []string
+
+	hostKey *sshHostKeyOptions
+}
+
+// WithDevices: named devices to select with DeviceClient.
+func (clt *Client) WithDevices(devices map[string]Device) (*Client, error) {
+	for name, device := range devices {
+		if len(device.HostKeys) == 0 && len(device.HostKeyFingerprints) == 0 {
+			continue
+		}
+		device.hostKey = &sshHostKeyOptions{}
+		for _, v := range device.HostKeys {
+			key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(v))
+			if err != nil {
+				return clt, fmt.Errorf("bad value for SSH host key %q of device %q: %w", v, name, err)
+			}
+			device.hostKey.publicKeys = append(device.hostKey.publicKeys, key)
+		}
+		for _, v := range device.HostKeyFingerprints {
+			if err := validateFingerprint(v); err != nil {
+				return clt, fmt.Errorf("bad value for SSH host key fingerprint of device %q: %w", name, err)
+			}
+			device.hostKey.fingerprints = append(device.hostKey.fingerprints, v)
+		}
+		devices[name] = device
+	}
+	clt.devices = devices
+
+	return clt, nil
+}
+
+// DeviceNames return the sorted list of names of devices.
+func (clt *Client) DeviceNames() []string {
+	names := make([]string, 0, len(clt.devices))
+	for name := range clt.devices {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// DeviceClient return the client of device with name
+// (generated on first call then reused) or the client itself if name is empty.
+func (clt *Client) DeviceClient(name string) (*Client, error) {
+	if name == "" {
+		return clt, nil
+	}
+	device, ok := clt.devices[name]
+	if !ok {
+		return nil, fmt.Errorf("device %q not found in devices of provider", name)
+	}
+
+	clt.mutexDeviceClients.Lock()
+	defer clt.mutexDeviceClients.Unlock()
+
+	if deviceClient, ok := clt.deviceClients[name]; ok {
+		return deviceClient, nil
+	}
+	if clt.deviceClients == nil {
+		clt.deviceClients = make(map[string]*Client)
+	}
+	deviceClient := clt.newDeviceClient(name, device)
+	clt.deviceClients[name] = deviceClient
+
+	return deviceClient, nil
+}
+
+// newDeviceClient generate a new client with options of client overridden by options of device.
+func (clt *Client) newDeviceClient(name string, device Device) *Client {
+	deviceClient := &Client{
+		junosIP:                         device.IP,
+		junosPort:                       clt.junosPort,
+		junosUserName:                   clt.junosUserName,
+		junosPassword:                   clt.junosPassword,
+		junosOTP:                        clt.junosOTP,
+		junosOTPFile:                    clt.junosOTPFile,
+		junosSSHKeyPEM:                  clt.junosSSHKeyPEM,
+		junosSSHKeyFile:                 clt.junosSSHKeyFile,
+		junosSSHKeyPass:                 clt.junosSSHKeyPass,
+		junosSSHCertPEM:                 clt.junosSSHCertPEM,
+		junosSSHCertFile:                clt.junosSSHCertFile,
+		groupIntDel:                     clt.groupIntDel,
+		decodeSecrets:                   clt.decodeSecrets,
+		sleepShort:                      clt.sleepShort,
+		sleepLock:                       clt.sleepLock,
+		junosCommitConfirmed:            clt.junosCommitConfirmed,
+		junosCommitConfirmedWaitPercent: clt.junosCommitConfirmedWaitPercent,
+		healthProbes:                    clt.healthProbes,
+		sleepSSHClosed:                  clt.sleepSSHClosed,
+		junosSSHCiphers:                 clt.junosSSHCiphers,
+		junosSSHTimeoutToEstab:          clt.junosSSHTimeoutToEstab,
+		junosSSHRetryToEstab:            clt.junosSSHRetryToEstab,
+		junosSSHHostKey:                 clt.junosSSHHostKey,
+		junosSSHJumpHosts:               clt.junosSSHJumpHosts,
+		junosProxy:                      clt.junosProxy,
+		filePermission:                  clt.filePermission,
+		logFileDst:                      clt.logFileDst,
+		fakeCreateSetFile:               "",
+		fakeUpdateAlso:                  clt.fakeUpdateAlso,
+		fakeDeleteAlso:                  clt.fakeDeleteAlso,
+		singleSession:                   clt.singleSession,
+		configMode:                      clt.configMode,
+		commitSynchronize:               clt.commitSynchronize,
+		planCommitCheck:                 clt.planCommitCheck,
+		planJunosDiff:                   clt.planJunosDiff,
+		planPhase:                       clt.planPhase,
+		netconfRecordFile:               clt.netconfRecordFile,
+		netconfReplay:                   clt.netconfReplay,
+	}
+	if device.Port != 0 {
+		deviceClient.junosPort = device.Port
+	}
+	if device.UserName != "" {
+		deviceClient.junosUserName = device.UserName
+	}
+	if device.Password != "" {
+		deviceClient.junosPassword = device.Password
+	}
+	if device.SSHKeyPEM != "" {
+		deviceClient.junosSSHKeyPEM = device.SSHKeyPEM
+	}
+	if device.SSHKeyFile != "" {
+		deviceClient.junosSSHKeyFile = device.SSHKeyFile
+	}
+	if device.SSHKeyPass != "" {
+		deviceClient.junosSSHKeyPass = device.SSHKeyPass
+	}
+	if device.SSHCertPEM != "" {
+		deviceClient.junosSSHCertPEM = device.SSHCertPEM
+	}
+	if device.SSHCertFile != "" {
+		deviceClient.junosSSHCertFile = device.SSHCertFile
+	}
+	if device.hostKey != nil {
+		// the known_hosts file of client is also used for the device
+		deviceClient.junosSSHHostKey = &sshHostKeyOptions{
+			publicKeys:     device.hostKey.publicKeys,
+			fingerprints:   device.hostKey.fingerprints,
+			knownHostsFile: clt.junosSSHHostKey.knownHostsFile,
+			trustFirstUse:  clt.junosSSHHostKey.trustFirstUse,
+		}
+	}
+	// sessions and commits are not shared between devices
+	if clt.sessionPool != nil {
+		deviceClient.sessionPool = newSessionPool(clt.sessionPool.size, clt.sessionPool.idleTimeout)
+	}
+	if clt.commitBatch != nil {
+		deviceClient.commitBatch = newCommitBatch(clt.commitBatch.window, deviceClient.newSession)
+	}
+	if clt.healthProbeFailure != nil {
+		deviceClient.healthProbeFailure = &healthProbeFailure{}
+	}
+	if clt.deferredCommitConfirm != nil {
+		deviceClient.deferredCommitConfirm = newDeferredCommitConfirm(deviceClient.newSession)
+	}
+	// don't mix set lines of devices in the same file
+	if clt.fakeCreateSetFile != "" {
+		deviceClient.fakeCreateSetFile = clt.fakeCreateSetFile + "." + name
+	}
+
+	return deviceClient
+}
@@ -0,0 +1,49 @@
+package junos
+
+import (
+	"testing"
+)
+
+func TestClientDeviceClient(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClient("192.0.2.1").
+		WithPort(22).
+		WithUserName("admin").
+		WithPassword("pass").
+		WithDevices(map[string]Device{
+			"sw1": {IP: "192.0.2.11"},
+			"sw2": {IP: "192.0.2.12", Port: 830, UserName: "netconf"},
+		})
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+
+	if c, err := client.DeviceClient(""); err != nil || c != client {
+		t.Errorf("expected client itself with empty name, got %v (err: %v)", c, err)
+	}
+	if _, err := client.DeviceClient("sw3"); err == nil {
+		t.Errorf("expected error with unknown device but got none")
+	}
+
+	sw1, err := client.DeviceClient("sw1")
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+	if sw1.junosIP != "192.0.2.11" || sw1.junosPort != 22 ||
+		sw1.junosUserName != "admin" || sw1.junosPassword != "pass" {
+		t.Errorf("unexpected options for sw1: %s:%d %s", sw1.junosIP, sw1.junosPort, sw1.junosUserName)
+	}
+	if sw1Again, _ := client.DeviceClient("sw1"); sw1Again != sw1 {
+		t.Errorf("expected the same client for the same device")
+	}
+
+	sw2, err := client.DeviceClient("sw2")
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+	if sw2.junosIP != "192.0.2.12" || sw2.junosPort != 830 ||
+		sw2.junosUserName != "netconf" || sw2.junosPassword != "pass" {
+		t.Errorf("unexpected options for sw2: %s:%d %s", sw2.junosIP, sw2.junosPort, sw2.junosUserName)
+	}
+}
@@ -0,0 +1,350 @@
+package junos
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HealthProbe: operational RPC to run after a commit with 'confirmed' option
+// and before its confirmation, with an assertion on the reply.
+//
+// The nodes selected by XPath in the reply of RPC need to exist
+// and, if ExpectedValue is not empty, all need to have this value.
+type HealthProbe struct {
+	Name          string
+	RPC           string
+	XPath         string
+	ExpectedValue string
+	Attempts      int
+	Interval      time.Duration
+
+	xpath []xpathStep
+}
+
+// healthProbeFailure: the first failure of health probes with a client,
+// after it the commits and the confirmations are refused for the rest of run
+// to not confirm the configuration which failed (the device rolls back it).
+type healthProbeFailure struct {
+	err   error
+	mutex sync.Mutex
+}
+
+// WithHealthProbes: run the health probes after a commit with 'confirmed' option
+// and don't confirm the commit if one of them fails (the device rolls back the configuration).
+func (clt *Client) WithHealthProbes(probes []HealthProbe) (*Client, error) {
+	if clt.junosCommitConfirmed == 0 {
+		return clt, errors.New("timeout of commit confirmed need to be set to use health probes")
+	}
+	for i := range probes {
+		if err := probes[i].init(); err != nil {
+			return clt, fmt.Errorf("health probe %s: %w", probes[i].name(i), err)
+		}
+	}
+	clt.healthProbes = probes
+	clt.healthProbeFailure = &healthProbeFailure{}
+
+	return clt, nil
+}
+
+func (probe *HealthProbe) init() error {
+	if strings.TrimSpace(probe.RPC) == "" {
+		return errors.New("empty RPC")
+	}
+	if probe.Attempts < 1 {
+		return errors.New("bad value for attempts, must be at least 1")
+	}
+	if probe.Interval < 0 {
+		return errors.New("bad value for interval, must be positive")
+	}
+	steps, err := parseXPath(probe.XPath)
+	if err != nil {
+		return err
+	}
+	probe.xpath = steps
+
+	return nil
+}
+
+func (probe *HealthProbe) name(index int) string {
+	if probe.Name != "" {
+		return fmt.Sprintf("%q", probe.Name)
+	}
+
+	return fmt.Sprintf("#%d", index+1)
+}
+
+// check the assertion of probe on the reply of RPC.
+func (probe *HealthProbe) check(reply string) error {
+	var root xmlNode
+	if err := xml.Unmarshal([]byte("<reply>"+reply+"</reply>"), &root); err != nil {
+		return fmt.Errorf("unmarshaling xml reply of RPC: %w", err)
+	}
+	nodes := evalXPath(&root, probe.xpath)
+	if len(nodes) == 0 {
+		return fmt.Errorf("no node found with xpath %q", probe.XPath)
+	}
+	if probe.ExpectedValue == "" {
+		return nil
+	}
+	for _, node := range nodes {
+		if v := strings.TrimSpace(node.Content); v != probe.ExpectedValue {
+			return fmt.Errorf("node %q found with xpath %q has value %q instead of %q",
+				node.XMLName.Local, probe.XPath, v, probe.ExpectedValue)
+		}
+	}
+
+	return nil
+}
+
+// runHealthProbes run the health probes of session and return the error of first failed probe.
+//
+// After a failed probe, the next runs fail without running the probes.
+func (sess *Session) runHealthProbes(ctx context.Context) error {
+	if err := sess.healthProbeFailure.failed(); err != nil {
+		return err
+	}
+	for i, probe := range sess.healthProbes {
+		if err := sess.runHealthProbe(ctx, probe); err != nil {
+			err = fmt.Errorf("health probe %s failed: %w", probe.name(i), err)
+			sess.healthProbeFailure.record(err)
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// record the first failure of health probes.
+func (failure *healthProbeFailure) record(err error) {
+	if failure == nil {
+		return
+	}
+	failure.mutex.Lock()
+	defer failure.mutex.Unlock()
+
+	if failure.err == nil {
+		failure.err = err
+	}
+}
+
+// failed return an error if health probes failed before.
+func (failure *healthProbeFailure) failed() error {
+	if failure == nil {
+		return nil
+	}
+	failure.mutex.Lock()
+	defer failure.mutex.Unlock()
+
+	if failure.err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("commits and confirmations refused after a previous failure of health probes: %w", failure.err)
+}
+
+func (sess *Session) runHealthProbe(ctx context.Context, probe HealthProbe) error {
+	for attempt := 1; ; attempt++ {
+		reply, err := sess.netconfCommandXML(probe.RPC)
+		if err == nil {
+			err = probe.check(reply)
+		}
+		if err == nil {
+			sess.logFile(fmt.Sprintf("[runHealthProbe] %q passed", probe.RPC))
+
+			return nil
+		}
+		sess.logFile(fmt.Sprintf("[runHealthProbe] %q attempt %d err: %q", probe.RPC, attempt, err))
+		if attempt >= probe.Attempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("health probe aborted after error: %w", err)
+		case <-time.After(probe.Interval):
+		}
+	}
+}
+
+// xmlNode: generic XML element to evaluate XPath.
+type xmlNode struct {
+	XMLName xml.Name
+	Content string    `xml:",chardata"`
+	Nodes   []xmlNode `xml:",any"`
+}
+
+// xpathStep: step of the XPath subset supported by health probes:
+// element name (or *) with an optional predicate on child element ([name] or [name='value'])
+// and the descendant axis with //.
+type xpathStep struct {
+	descendant bool
+	name       string
+	predicates []xpathPredicate
+}
+
+type xpathPredicate struct {
+	child    string
+	value    string
+	hasValue bool
+}
+
+func parseXPath(xpath string) ([]xpathStep, error) {
+	xpath = strings.TrimSuffix(strings.TrimSpace(xpath), "/text()")
+	if xpath == "" {
+		return nil, errors.New("empty xpath")
+	}
+	var steps []xpathStep
+	descendant := false
+	for i, v := range splitXPath(strings.TrimPrefix(xpath, "/")) {
+		if v == "" {
+			if i == 0 && !strings.HasPrefix(xpath, "/") {
+				return nil, fmt.Errorf("bad xpath %q: empty step", xpath)
+			}
+			descendant = true
+
+			continue
+		}
+		step, err := parseXPathStep(v)
+		if err != nil {
+			return nil, fmt.Errorf("bad xpath %q: %w", xpath, err)
+		}
+		step.descendant = descendant
+		descendant = false
+		steps = append(steps, step)
+	}
+	if descendant || len(steps) == 0 {
+		return nil, fmt.Errorf("bad xpath %q: missing step at end", xpath)
+	}
+
+	return steps, nil
+}
+
+// splitXPath split xpath on / outside of predicates.
+func splitXPath(xpath string) []string {
+	var parts []string
+	var quote rune
+	depth := 0
+	start := 0
+	for i, c := range xpath {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case c == '/' && depth == 0:
+			parts = append(parts, xpath[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, xpath[start:])
+}
+
+func parseXPathStep(v string) (xpathStep, error) {
+	var step xpathStep
+	name, predicates, _ := strings.Cut(v, "[")
+	step.name = strings.TrimSpace(name)
+	if step.name == "" {
+		return step, fmt.Errorf("missing element name in step %q", v)
+	}
+	if predicates == "" {
+		return step, nil
+	}
+	for predicate := range strings.SplitSeq(strings.TrimSuffix(predicates, "]"), "][") {
+		child, value, hasValue := strings.Cut(predicate, "=")
+		p := xpathPredicate{
+			child:    strings.TrimSpace(child),
+			hasValue: hasValue,
+		}
+		if hasValue {
+			value = strings.TrimSpace(value)
+			if len(value) < 2 ||
+				(value[0] != '\'' && value[0] != '"') ||
+				value[len(value)-1] != value[0] {
+				return step, fmt.Errorf("value need to be quoted in predicate %q", predicate)
+			}
+			p.value = value[1 : len(value)-1]
+		}
+		if p.child == "" {
+			return step, fmt.Errorf("missing child element name in predicate %q", predicate)
+		}
+		step.predicates = append(step.predicates, p)
+	}
+
+	return step, nil
+}
+
+func (step xpathStep) match(node *xmlNode) bool {
+	if step.name != "*" && step.name != node.XMLName.Local {
+		return false
+	}
+	for _, p := range step.predicates {
+		found := false
+		for i := range node.Nodes {
+			child := &node.Nodes[i]
+			if child.XMLName.Local != p.child {
+				continue
+			}
+			if !p.hasValue || strings.TrimSpace(child.Content) == p.value {
+				found = true
+
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// evalXPath return the nodes selected by steps from root.
+func evalXPath(root *xmlNode, steps []xpathStep) []*xmlNode {
+	current := []*xmlNode{root}
+	for _, step := range steps {
+		var next []*xmlNode
+		seen := make(map[*xmlNode]struct{})
+		add := func(node *xmlNode) {
+			if _, ok := seen[node]; ok {
+				return
+			}
+			if step.match(node) {
+				seen[node] = struct{}{}
+				next = append(next, node)
+			}
+		}
+		for _, node := range current {
+			if step.descendant {
+				walkXMLNodes(node, add)
+			} else {
+				for i := range node.Nodes {
+					add(&node.Nodes[i])
+				}
+			}
+		}
+		current = next
+	}
+
+	return current
+}
+
+// walkXMLNodes call f for each descendant of node.
+func walkXMLNodes(node *xmlNode, f func(*xmlNode)) {
+	for i := range node.Nodes {
+		f(&node.Nodes[i])
+		walkXMLNodes(&node.Nodes[i], f)
+	}
+}
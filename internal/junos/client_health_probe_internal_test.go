@@ -0,0 +1,155 @@
+package junos
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestHealthProbeCheck(t *testing.T) {
+	t.Parallel()
+
+	replyBGP := `<bgp-information xmlns="http://xml.juniper.net/junos/23.4R1/junos-routing">
+<bgp-peer>
+<peer-address>192.0.2.1+179</peer-address>
+<peer-state>Established</peer-state>
+</bgp-peer>
+<bgp-peer>
+<peer-address>192.0.2.2+179</peer-address>
+<peer-state>Active</peer-state>
+</bgp-peer>
+</bgp-information>`
+	replyPing := `<ping-results><probe-results-summary><packet-loss>0</packet-loss></probe-results-summary>
+<ping-success/></ping-results>`
+
+	type testCase struct {
+		xpath         string
+		expectedValue string
+		reply         string
+		expectError   bool
+	}
+	tests := map[string]testCase{
+		"all_peers_established": {
+			xpath:         "//bgp-peer/peer-state",
+			expectedValue: "Established",
+			reply:         replyBGP,
+			expectError:   true,
+		},
+		"peer_established": {
+			xpath:         "//bgp-peer[peer-address='192.0.2.1+179']/peer-state",
+			expectedValue: "Established",
+			reply:         replyBGP,
+		},
+		"peer_not_found": {
+			xpath:       "//bgp-peer[peer-address='192.0.2.3+179']",
+			reply:       replyBGP,
+			expectError: true,
+		},
+		"absolute_path": {
+			xpath:         "/bgp-information/bgp-peer[peer-state=\"Active\"]/peer-address/text()",
+			expectedValue: "192.0.2.2+179",
+			reply:         replyBGP,
+		},
+		"ping_success": {
+			xpath: "//ping-success",
+			reply: replyPing,
+		},
+		"ping_loss": {
+			xpath:         "ping-results/*/packet-loss",
+			expectedValue: "0",
+			reply:         replyPing,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			probe := HealthProbe{
+				RPC:           "<get-rpc/>",
+				XPath:         test.xpath,
+				ExpectedValue: test.expectedValue,
+				Attempts:      1,
+			}
+			if err := probe.init(); err != nil {
+				t.Fatalf("got unexpected error on init: %s", err)
+			}
+			err := probe.check(test.reply)
+			if test.expectError && err == nil {
+				t.Errorf("expected error but got none")
+			} else if !test.expectError && err != nil {
+				t.Errorf("got unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestHealthProbeInitBadXPath(t *testing.T) {
+	t.Parallel()
+
+	for _, xpath := range []string{"", "//", "bgp-peer/", "bgp-peer[peer-state=Established]", "[peer-state]"} {
+		probe := HealthProbe{
+			RPC:      "<get-rpc/>",
+			XPath:    xpath,
+			Attempts: 1,
+		}
+		if err := probe.init(); err == nil {
+			t.Errorf("expected error with xpath %q but got none", xpath)
+		}
+	}
+}
+
+func TestHealthProbeFailureRefuseCommits(t *testing.T) {
+	t.Parallel()
+
+	srv, clt := newSimulatorClient(t)
+	clt, err := clt.WithCommitConfirmed(5)
+	if err == nil {
+		clt, err = clt.WithCommitConfirmedWaitPercent(0)
+	}
+	if err == nil {
+		clt, err = clt.WithHealthProbes([]HealthProbe{{
+			RPC:           "<get-system-information/>",
+			XPath:         "host-name",
+			ExpectedValue: "other",
+			Attempts:      1,
+		}})
+	}
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+
+	ctx := context.Background()
+	commit := func(line string) error {
+		sess, err := clt.StartNewSession(ctx)
+		if err != nil {
+			t.Fatalf("starting session: %s", err)
+		}
+		defer sess.Close()
+		if err := sess.ConfigLock(ctx); err != nil {
+			t.Fatalf("got unexpected error on lock: %s", err)
+		}
+		defer sess.ConfigUnlock(ctx)
+		if err := sess.ConfigSet(ctx, []string{line}); err != nil {
+			t.Fatalf("got unexpected error on set: %s", err)
+		}
+		_, err = sess.CommitConf(ctx, "test")
+
+		return err
+	}
+
+	if err := commit("set vlans vlan10 vlan-id 10"); err == nil {
+		t.Fatalf("expected error of health probe but got none")
+	}
+	if err := commit("set vlans vlan20 vlan-id 20"); err == nil ||
+		!strings.Contains(err.Error(), "refused after a previous failure of health probes") {
+		t.Errorf("expected error of commit refused but got %v", err)
+	}
+	if v := len(srv.Commits()); v != 1 {
+		t.Errorf("got %d commits, expected only the commit before the failure of health probe", v)
+	}
+	if _, err := clt.ConfirmCommit(ctx); err == nil ||
+		!strings.Contains(err.Error(), "refused after a previous failure of health probes") {
+		t.Errorf("expected error of confirmation refused but got %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+package junos
+
+import (
+	"testing"
+)
+
+func TestClientWithConfigMode(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("192.0.2.1")
+	if client.configMode != ConfigModeShared {
+		t.Errorf("got default configuration mode %q, expected %q", client.configMode, ConfigModeShared)
+	}
+	for _, mode := range []string{ConfigModeShared, ConfigModePrivate, ConfigModeExclusive} {
+		if _, err := client.WithConfigMode(mode); err != nil {
+			t.Errorf("got unexpected error with mode %q: %s", mode, err)
+		}
+		if client.configMode != mode {
+			t.Errorf("got configuration mode %q, expected %q", client.configMode, mode)
+		}
+	}
+	if _, err := client.WithConfigMode("dynamic"); err == nil {
+		t.Errorf("expected error with unknown mode but got none")
+	}
+
+	if _, err := client.WithDevices(map[string]Device{"sw1": {IP: "192.0.2.11"}}); err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+	sw1, err := client.DeviceClient("sw1")
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+	if sw1.configMode != ConfigModeExclusive {
+		t.Errorf("got configuration mode %q for device, expected %q", sw1.configMode, ConfigModeExclusive)
+	}
+}
@@ -0,0 +1,246 @@
+package junos
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/jeremmfr/go-netconf/netconf"
+)
+
+var (
+	netconfMessageIDRegexp = regexp.MustCompile(` message-id="[^"]*"`)
+
+	// mutex to append the exchanges in record files.
+	mutexNetconfRecord sync.Mutex
+
+	// replays of fixture files, shared between clients to keep the position of replies
+	// when the provider is configured multiple times in the same process (steps of a test).
+	netconfReplays      = make(map[string]*netconfReplay)
+	mutexNetconfReplays sync.Mutex
+)
+
+// netconfExchange: a request sent to a device and its raw reply
+// (a line in JSON format in a fixture file).
+type netconfExchange struct {
+	Host    string `json:"host"`
+	Request string `json:"request"`
+	Reply   string `json:"reply"`
+}
+
+// netconfReplay: the recorded replies to replay, for each host and request.
+type netconfReplay struct {
+	file    string
+	replies map[string][]string
+	mutex   sync.Mutex
+}
+
+// WithNetconfRecordFile: record the netconf exchanges with devices in a fixture file
+// to replay them later with WithNetconfReplayFile.
+func (clt *Client) WithNetconfRecordFile(file string) (*Client, error) {
+	if clt.netconfReplay != nil {
+		return clt, errors.New("netconf record and replay can't be enabled at the same time")
+	}
+	clt.netconfRecordFile = file
+
+	return clt, nil
+}
+
+// WithNetconfReplayFile: replay the netconf exchanges recorded in a fixture file
+// instead of connecting to devices.
+func (clt *Client) WithNetconfReplayFile(file string) (*Client, error) {
+	if clt.netconfRecordFile != "" {
+		return clt, errors.New("netconf record and replay can't be enabled at the same time")
+	}
+	replay, err := loadNetconfReplay(file)
+	if err != nil {
+		return clt, err
+	}
+	clt.netconfReplay = replay
+
+	return clt, nil
+}
+
+// netconfFixtureRequest normalize the request to be able to find it in a fixture file
+// (with the secrets redacted).
+func netconfFixtureRequest(request []byte) string {
+	return RedactSecrets(strings.TrimSpace(netconfMessageIDRegexp.ReplaceAllString(string(request), "")))
+}
+
+func (clt *Client) appendNetconfRecordFile(exchange netconfExchange) error {
+	line, err := json.Marshal(exchange)
+	if err != nil {
+		return fmt.Errorf("encoding netconf exchange: %w", err)
+	}
+
+	mutexNetconfRecord.Lock()
+	defer mutexNetconfRecord.Unlock()
+
+	dirRecordFile := path.Dir(clt.netconfRecordFile)
+	if _, err := os.Stat(dirRecordFile); err != nil {
+		if err := os.MkdirAll(dirRecordFile, os.FileMode(directoryPermission)); err != nil {
+			return fmt.Errorf("creating parent directory of '%s': %w", clt.netconfRecordFile, err)
+		}
+	}
+	f, err := os.OpenFile(clt.netconfRecordFile,
+		os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(clt.filePermission))
+	if err != nil {
+		return fmt.Errorf("opening file '%s': %w", clt.netconfRecordFile, err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("writing in file '%s': %w", clt.netconfRecordFile, err)
+	}
+
+	return nil
+}
+
+// loadNetconfReplay read a fixture file or return the replay already loaded for this file.
+func loadNetconfReplay(file string) (*netconfReplay, error) {
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading absolute path of '%s': %w", file, err)
+	}
+
+	mutexNetconfReplays.Lock()
+	defer mutexNetconfReplays.Unlock()
+
+	if replay, ok := netconfReplays[absFile]; ok {
+		return replay, nil
+	}
+
+	f, err := os.Open(absFile)
+	if err != nil {
+		return nil, fmt.Errorf("opening file '%s': %w", file, err)
+	}
+	defer f.Close()
+
+	replay := &netconfReplay{
+		file:    file,
+		replies: make(map[string][]string),
+	}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var exchange netconfExchange
+		if err := json.Unmarshal(scanner.Bytes(), &exchange); err != nil {
+			return nil, fmt.Errorf("decoding line %d of file '%s': %w", lineNumber, file, err)
+		}
+		key := exchange.Host + "\n" + exchange.Request
+		replay.replies[key] = append(replay.replies[key], exchange.Reply)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading file '%s': %w", file, err)
+	}
+	netconfReplays[absFile] = replay
+
+	return replay, nil
+}
+
+// nextReply return the next recorded reply to the request for the host.
+func (replay *netconfReplay) nextReply(host, request string) (string, error) {
+	replay.mutex.Lock()
+	defer replay.mutex.Unlock()
+
+	key := host + "\n" + request
+	replies := replay.replies[key]
+	if len(replies) == 0 {
+		if request == rpcCloseSession || strings.HasSuffix(request, rpcCloseSession+"</rpc>") {
+			return "<rpc-reply><ok/></rpc-reply>", nil
+		}
+
+		return "", fmt.Errorf("no more recorded reply in '%s' for request %q to %s", replay.file, request, host)
+	}
+	replay.replies[key] = replies[1:]
+
+	return replies[0], nil
+}
+
+// newSession open a session which replays the recorded replies for the host.
+func (replay *netconfReplay) newSession(host string) (*Session, error) {
+	netconfSess := netconf.NewSession(&netconfReplayTransport{
+		host:   host,
+		replay: replay,
+	})
+
+	return newSessionFromNetconf(netconfSess, "replay", host)
+}
+
+// netconfRecordTransport: netconf transport which records the exchanges.
+type netconfRecordTransport struct {
+	netconf.Transport
+
+	host        string
+	lastRequest []byte
+	record      func(netconfExchange) error
+}
+
+func (t *netconfRecordTransport) Send(data []byte) error {
+	t.lastRequest = data
+
+	return t.Transport.Send(data)
+}
+
+func (t *netconfRecordTransport) Receive() ([]byte, error) {
+	reply, err := t.Transport.Receive()
+	if err != nil {
+		return reply, err
+	}
+	if err := t.record(netconfExchange{
+		Host:    t.host,
+		Request: netconfFixtureRequest(t.lastRequest),
+		Reply:   RedactSecrets(string(reply)),
+	}); err != nil {
+		return reply, fmt.Errorf("recording netconf exchange: %w", err)
+	}
+
+	return reply, nil
+}
+
+// netconfReplayTransport: netconf transport which replays the recorded replies without device.
+type netconfReplayTransport struct {
+	host        string
+	replay      *netconfReplay
+	lastRequest []byte
+}
+
+func (t *netconfReplayTransport) Send(data []byte) error {
+	t.lastRequest = data
+
+	return nil
+}
+
+func (t *netconfReplayTransport) Receive() ([]byte, error) {
+	reply, err := t.replay.nextReply(t.host, netconfFixtureRequest(t.lastRequest))
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(reply), nil
+}
+
+func (t *netconfReplayTransport) Close() error {
+	return nil
+}
+
+func (t *netconfReplayTransport) ReceiveHello() (*netconf.HelloMessageReceive, error) {
+	return &netconf.HelloMessageReceive{
+		Capabilities: netconf.DefaultCapabilities,
+	}, nil
+}
+
+func (t *netconfReplayTransport) SendHello(*netconf.HelloMessageSend) error {
+	return nil
+}
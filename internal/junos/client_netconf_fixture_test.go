@@ -0,0 +1,79 @@
+package junos_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+	"github.com/jeremmfr/terraform-provider-junos/internal/junostest"
+)
+
+func TestNetconfRecordReplay(t *testing.T) {
+	t.Parallel()
+
+	fixtureFile := filepath.Join(t.TempDir(), "fixtures", "netconf.jsonl")
+	srv := junostest.NewTestServer(t)
+	ip, port := srv.IP(), srv.Port()
+
+	run := func(clt *junos.Client) string {
+		t.Helper()
+
+		ctx := context.Background()
+		junSess, err := clt.StartNewSession(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		defer junSess.Close()
+		if err := junSess.ConfigLock(ctx); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := junSess.ConfigSet(ctx, []string{"set system host-name recorded"}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if _, err := junSess.CommitConf(ctx, "record"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if errs := junSess.ConfigUnlock(ctx); len(errs) > 0 {
+			t.Fatalf("unexpected errors: %v", errs)
+		}
+		showConfig, err := junSess.Command(ctx, junos.CmdShowConfig+"system host-name"+junos.PipeDisplaySetRelative)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		return showConfig
+	}
+	newClient := func() *junos.Client {
+		return junos.NewClient(ip).
+			WithPort(port).
+			WithUserName("test").
+			WithPassword("test").
+			WithSleepShort(0)
+	}
+
+	recordClt, err := newClient().WithNetconfRecordFile(fixtureFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	recorded := run(recordClt)
+	srv.Close()
+
+	replayClt, err := newClient().WithNetconfReplayFile(fixtureFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := replayClt.WithNetconfRecordFile(fixtureFile); err == nil {
+		t.Errorf("expected error when enabling record with replay")
+	}
+	if replayed := run(replayClt); replayed != recorded {
+		t.Errorf("got unexpected output on replay: %q, want %q", replayed, recorded)
+	}
+
+	// all recorded replies have been consumed
+	junSess, err := replayClt.StartNewSession(context.Background())
+	if err == nil {
+		junSess.Close()
+		t.Errorf("expected error when no more recorded reply")
+	}
+}
@@ -0,0 +1,137 @@
+package junos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/jeremmfr/go-netconf/netconf"
+)
+
+// NewLinesCaptureClient return a client without connection to the device
+// which generates the set/delete lines of resource actions like with the fake options
+// (create, update and delete) but give them to capture instead of appending them to a file.
+func (clt *Client) NewLinesCaptureClient(capture func([]string) error) *Client {
+	return &Client{
+		junosPort:        clt.junosPort,
+		junosUserName:    clt.junosUserName,
+		groupIntDel:      clt.groupIntDel,
+		decodeSecrets:    clt.decodeSecrets,
+		junosSSHHostKey:  &sshHostKeyOptions{},
+		filePermission:   clt.filePermission,
+		fakeUpdateAlso:   true,
+		fakeDeleteAlso:   true,
+		fakeCaptureLines: capture,
+		configMode:       clt.configMode,
+	}
+}
+
+// planPhase: the plan phase of a Terraform operation,
+// shared by the client of provider and the clients of devices.
+//
+// The provider is configured (and a new client generated) for each operation of Terraform,
+// so the plan phase ends only once, when the first resource action is applied.
+// The resources planned after are the replans of apply phase.
+type planPhase struct {
+	ended atomic.Bool
+}
+
+// EndPlanPhase mark the end of plan phase (when a resource action is applied).
+func (clt *Client) EndPlanPhase() {
+	if clt == nil || clt.planPhase == nil {
+		return
+	}
+	clt.planPhase.ended.Store(true)
+}
+
+// InPlanPhase return true if no resource action has been applied with this client
+// (or the clients of devices).
+func (clt *Client) InPlanPhase() bool {
+	return clt.planPhase != nil && !clt.planPhase.ended.Load()
+}
+
+// CommitCheckPlanLines check the commit of set/delete lines of a resource plan
+// in a private candidate configuration which also contains the lines of resources
+// checked before (to have the configuration of resources planned before, like the references).
+//
+// Only the errors on the lines of the resource are returned as error,
+// the lines in error are not kept for the checks of next resources.
+//
+// The lines are kept only during the plan phase, after it (replans of apply phase)
+// the check is skipped and the lines of resources checked before are dropped.
+func (clt *Client) CommitCheckPlanLines(ctx context.Context, lines []string) ([]error, error) {
+	if len(lines) == 0 {
+		return nil, nil
+	}
+	clt.mutexPlanCheckLines.Lock()
+	if !clt.InPlanPhase() {
+		clt.planCheckedLines = nil
+		clt.mutexPlanCheckLines.Unlock()
+
+		return nil, nil
+	}
+	previousLines := slices.Clone(clt.planCheckedLines)
+	clt.planCheckedLines = append(clt.planCheckedLines, lines...)
+	clt.mutexPlanCheckLines.Unlock()
+
+	sess, err := clt.StartNewSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.Close()
+
+	warnings, err := sess.ConfigCommitCheck(ctx, append(previousLines, lines...))
+	if err == nil {
+		return warnings, nil
+	}
+	if errors.Is(err, errOpenPrivateConfiguration) {
+		// like when the candidate configuration is locked by an other session
+		return append(warnings, fmt.Errorf("commit check skipped: %w", err)), nil
+	}
+
+	var commitErr *CommitError
+	var rpcErr *netconf.RPCError
+	if !errors.As(err, &commitErr) && !errors.As(err, &rpcErr) {
+		// not an error of commit check
+		return warnings, err
+	}
+	entry := &commitBatchEntry{
+		loads: []commitBatchLoad{{
+			action: LoadConfigActionSet,
+			config: strings.Join(lines, "\n"),
+		}},
+	}
+	failed, _ := attributeCommitBatchError([]*commitBatchEntry{entry}, err)
+	if errs, ok := failed[entry]; ok || len(previousLines) == 0 {
+		clt.removePlanCheckedLines(lines)
+		if !ok {
+			return warnings, fmt.Errorf("commit check failed:\n%w", err)
+		}
+
+		return warnings, errors.New("commit check failed:\n" + strings.Join(errs, "\n"))
+	}
+
+	return append(warnings, fmt.Errorf(
+		"commit check failed but not on the lines of this resource "+
+			"(maybe on the lines of resources planned before):\n%w", err,
+	)), nil
+}
+
+func (clt *Client) removePlanCheckedLines(lines []string) {
+	clt.mutexPlanCheckLines.Lock()
+	defer clt.mutexPlanCheckLines.Unlock()
+
+	for i := range clt.planCheckedLines {
+		if i+len(lines) > len(clt.planCheckedLines) {
+			return
+		}
+		if slices.Equal(clt.planCheckedLines[i:i+len(lines)], lines) {
+			clt.planCheckedLines = slices.Delete(clt.planCheckedLines, i, i+len(lines))
+
+			return
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package junos
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestNewLinesCaptureClient(t *testing.T) {
+	t.Parallel()
+
+	var captured []string
+	client := NewClient("").NewLinesCaptureClient(func(lines []string) error {
+		captured = append(captured, lines...)
+
+		return nil
+	})
+	if !client.FakeCreateSetFile() || !client.FakeUpdateAlso() || !client.FakeDeleteAlso() {
+		t.Fatalf("expected fake options enabled on capture client")
+	}
+
+	junSess := client.NewSessionWithoutNetconf(context.Background())
+	if err := junSess.ConfigSet(context.Background(), []string{"set vlans vlan10 vlan-id 10", "delete vlans vlan20"}); err != nil {
+		t.Fatalf("got unexpected error on set: %s", err)
+	}
+	if expected := []string{"set vlans vlan10 vlan-id 10", "delete vlans vlan20"}; !slices.Equal(captured, expected) {
+		t.Errorf("got captured lines %q, expected %q", captured, expected)
+	}
+}
+
+func TestClientRemovePlanCheckedLines(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("")
+	client.planCheckedLines = []string{
+		"set vlans vlan10 vlan-id 10",
+		"set vlans vlan20 vlan-id 20",
+		"set vlans vlan20 description test",
+		"set vlans vlan30 vlan-id 30",
+	}
+	client.removePlanCheckedLines([]string{"set vlans vlan20 vlan-id 20", "set vlans vlan20 description test"})
+	if expected := []string{
+		"set vlans vlan10 vlan-id 10",
+		"set vlans vlan30 vlan-id 30",
+	}; !slices.Equal(client.planCheckedLines, expected) {
+		t.Errorf("got lines %q, expected %q", client.planCheckedLines, expected)
+	}
+	client.removePlanCheckedLines([]string{"set vlans vlan40 vlan-id 40"})
+	if len(client.planCheckedLines) != 2 {
+		t.Errorf("got %d lines after removing unknown lines, expected 2", len(client.planCheckedLines))
+	}
+}
+
+func TestClientCommitCheckPlanLinesAfterPlanPhase(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("").WithPlanCommitCheck()
+	client.planCheckedLines = []string{"set vlans vlan10 vlan-id 10"}
+	deviceClient := client.newDeviceClient("device1", Device{})
+	if !deviceClient.InPlanPhase() {
+		t.Fatalf("expected device client in plan phase")
+	}
+	client.EndPlanPhase()
+	if deviceClient.InPlanPhase() {
+		t.Fatalf("expected end of plan phase shared with device client")
+	}
+
+	// without IP, the check would fail to open a session
+	warns, err := client.CommitCheckPlanLines(context.Background(), []string{"set vlans vlan20 vlan-id 20"})
+	if err != nil || len(warns) != 0 {
+		t.Errorf("got unexpected result after plan phase: %v, %v", warns, err)
+	}
+	if len(client.planCheckedLines) != 0 {
+		t.Errorf("got lines %q after plan phase, expected none", client.planCheckedLines)
+	}
+}
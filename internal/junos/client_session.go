@@ -3,12 +3,17 @@ package junos
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net"
 	"strconv"
-	"time"
+
+	"golang.org/x/crypto/ssh"
 )
 
 func (clt *Client) StartNewSession(ctx context.Context) (*Session, error) {
+	if clt.configSnapshot != nil {
+		return clt.newConfigSnapshotSession(), nil
+	}
 	if clt.singleSession {
 		clt.mutexSharedSession.Lock()
 		if clt.sharedSession != nil {
@@ -26,11 +31,17 @@ func (clt *Client) StartNewSession(ctx context.Context) (*Session, error) {
 
 		return clt.sharedSession, nil
 	}
+	if clt.sessionPool != nil {
+		return clt.sessionPool.acquire(ctx, clt.newSession)
+	}
 
 	return clt.newSession(ctx)
 }
 
 func (clt *Client) newSession(ctx context.Context) (*Session, error) {
+	if clt.junosIP == "" {
+		return nil, errors.New("missing IP target to connect to the Junos device")
+	}
 	var auth sshAuthMethod
 	auth.Username = clt.junosUserName
 	auth.Ciphers = clt.junosSSHCiphers
@@ -46,23 +57,54 @@ func (clt *Client) newSession(ctx context.Context) (*Session, error) {
 			auth.Passphrase = clt.junosSSHKeyPass
 		}
 	}
+	if clt.junosSSHCertPEM != "" {
+		auth.CertificatePEM = clt.junosSSHCertPEM
+	}
+	if clt.junosSSHCertFile != "" {
+		auth.CertificateFile = clt.junosSSHCertFile
+	}
 	if clt.junosPassword != "" {
 		auth.Password = clt.junosPassword
 	}
+	auth.OTP = clt.junosOTP
+	auth.OTPFile = clt.junosOTPFile
 	auth.Timeout = clt.junosSSHTimeoutToEstab
-	sess, err := netconfNewSession(
-		ctx,
-		net.JoinHostPort(clt.junosIP, strconv.Itoa(clt.junosPort)),
-		&auth,
-		&openSSHOptions{
-			Retry:   clt.junosSSHRetryToEstab,
-			Timeout: clt.junosSSHTimeoutToEstab,
-		},
-	)
+	auth.HostKeyCallback = clt.junosSSHHostKey.hostKeyCallback(net.JoinHostPort(clt.junosIP, strconv.Itoa(clt.junosPort)))
+	auth.agentConns = &sshAgentConns{}
+	jumpHosts, err := clt.sshJumpHostsOptions(auth.agentConns)
+	if err != nil {
+		_ = auth.agentConns.close()
+
+		return nil, err
+	}
+	openSSH := &openSSHOptions{
+		Retry:     clt.junosSSHRetryToEstab,
+		Timeout:   clt.junosSSHTimeoutToEstab,
+		JumpHosts: jumpHosts,
+		Proxy:     clt.junosProxy,
+	}
+	if clt.netconfRecordFile != "" {
+		openSSH.Record = clt.appendNetconfRecordFile
+	}
+	var sess *Session
+	if clt.netconfReplay != nil {
+		sess, err = clt.netconfReplay.newSession(net.JoinHostPort(clt.junosIP, strconv.Itoa(clt.junosPort)))
+	} else {
+		sess, err = netconfNewSession(
+			ctx,
+			net.JoinHostPort(clt.junosIP, strconv.Itoa(clt.junosPort)),
+			&auth,
+			openSSH,
+		)
+	}
+	if sess != nil {
+		sess.sshAgentConns = auth.agentConns
+	}
 	if err != nil {
 		if sess != nil && sess.netconf != nil {
 			_ = sess.closeNetconf(sess.sleepSSHClosed)
 		}
+		_ = auth.agentConns.close()
 
 		return nil, err
 	}
@@ -72,19 +114,15 @@ func (clt *Client) newSession(ctx context.Context) (*Session, error) {
 				fnCtx,
 				net.JoinHostPort(clt.junosIP, strconv.Itoa(clt.junosPort)),
 				&auth,
-				&openSSHOptions{
-					Retry:   clt.junosSSHRetryToEstab,
-					Timeout: clt.junosSSHTimeoutToEstab,
-				},
+				openSSH,
 			)
 		}
 	}
 	sess.commitConfirmedTimeout = clt.junosCommitConfirmed
-	sess.commitConfirmedWait = time.Duration(
-		int(
-			(time.Duration(clt.junosCommitConfirmed)*time.Minute).Microseconds(),
-		)*clt.junosCommitConfirmedWaitPercent/100,
-	) * time.Microsecond
+	sess.commitConfirmedWait = clt.commitConfirmedWait()
+	sess.deferredCommitConfirm = clt.deferredCommitConfirm
+	sess.healthProbes = clt.healthProbes
+	sess.healthProbeFailure = clt.healthProbeFailure
 	sess.logFile = func(message string) {
 		message = "[" + sess.localAddress + "->" + sess.remoteAddress + "]" + message
 		clt.logFile(message)
@@ -93,6 +131,8 @@ func (clt *Client) newSession(ctx context.Context) (*Session, error) {
 	sess.sleepLock = clt.sleepLock
 	sess.sleepShort = clt.sleepShort
 	sess.sleepSSHClosed = clt.sleepSSHClosed
+	sess.configMode = clt.configMode
+	sess.commitBatch = clt.commitBatch
 	if clt.fakeCreateSetFile != "" {
 		sess.fakeSetFile = clt.appendFakeCreateSetFile
 	}
@@ -101,17 +141,82 @@ func (clt *Client) newSession(ctx context.Context) (*Session, error) {
 
 		return nil, errors.New("can't read model of device with <get-system-information/> netconf command")
 	}
+	sess.commitSynchronize = clt.commitSynchronizeSession(sess)
 	sess.logFile("[StartNewSession] session opened")
 
 	return sess, nil
 }
 
+// sshJumpHostsOptions generate the SSH client configuration for each jump host
+// (the connections to the SSH agent are kept in agentConns).
+func (clt *Client) sshJumpHostsOptions(agentConns *sshAgentConns) ([]sshJumpHostOptions, error) {
+	if len(clt.junosSSHJumpHosts) == 0 {
+		return nil, nil
+	}
+
+	jumpHosts := make([]sshJumpHostOptions, len(clt.junosSSHJumpHosts))
+	for i, jumpHost := range clt.junosSSHJumpHosts {
+		host := net.JoinHostPort(jumpHost.IP, strconv.Itoa(jumpHost.Port))
+		auth := sshAuthMethod{
+			Password:        jumpHost.Password,
+			Username:        jumpHost.UserName,
+			PrivateKeyPEM:   jumpHost.SSHKeyPEM,
+			PrivateKeyFile:  jumpHost.SSHKeyFile,
+			Passphrase:      jumpHost.SSHKeyPass,
+			CertificatePEM:  jumpHost.SSHCertPEM,
+			CertificateFile: jumpHost.SSHCertFile,
+			Ciphers:         clt.junosSSHCiphers,
+			Timeout:         clt.junosSSHTimeoutToEstab,
+			agentConns:      agentConns,
+		}
+		var err error
+		auth.HostKeyCallback, err = clt.sshJumpHostKeyCallback(jumpHost, host)
+		if err != nil {
+			return nil, err
+		}
+		if auth.Username == "" {
+			auth.Username = clt.junosUserName
+		}
+		clientConfig, err := genSSHClientConfig(&auth)
+		if err != nil {
+			return nil, fmt.Errorf("SSH configuration for jump host %s: %w", host, err)
+		}
+		jumpHosts[i] = sshJumpHostOptions{
+			Host:         host,
+			ClientConfig: clientConfig,
+		}
+	}
+
+	return jumpHosts, nil
+}
+
+// sshJumpHostKeyCallback generate the ssh.HostKeyCallback to verify the SSH host key of a jump host
+// with its own options or with the known_hosts file of device (which can have several hosts).
+//
+// The host keys and fingerprints trusted for the device are never used for a jump host.
+func (clt *Client) sshJumpHostKeyCallback(jumpHost SSHJumpHost, host string) (ssh.HostKeyCallback, error) {
+	switch {
+	case jumpHost.hostKey != nil && jumpHost.hostKey.enabled():
+		return jumpHost.hostKey.hostKeyCallback(host), nil
+	case clt.junosSSHHostKey.knownHostsFile != "":
+		return clt.junosSSHHostKey.knownHostsCallback(host), nil
+	case clt.junosSSHHostKey.enabled():
+		return nil, fmt.Errorf("SSH host key of jump host %s can't be verified"+
+			" with the host keys or fingerprints trusted for the device:"+
+			" need host keys, fingerprints or known_hosts file for the jump host", host)
+	}
+
+	return ssh.InsecureIgnoreHostKey(), nil
+}
+
 func (clt *Client) NewSessionWithoutNetconf(_ context.Context) *Session {
 	sess := Session{
 		logFile:       clt.logFile,
 		decodeSecrets: clt.decodeSecrets,
 	}
-	if clt.fakeCreateSetFile != "" {
+	if clt.fakeCaptureLines != nil {
+		sess.fakeSetFile = clt.fakeCaptureLines
+	} else if clt.fakeCreateSetFile != "" {
 		sess.fakeSetFile = clt.appendFakeCreateSetFile
 	}
 
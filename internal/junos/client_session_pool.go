@@ -0,0 +1,170 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// sessionPool: bounded pool of NETCONF sessions reused between provider operations.
+//
+// At most size sessions are in use at the same time,
+// an idle session is closed after idleTimeout (if not zero)
+// and must pass the health check before being reused.
+type sessionPool struct {
+	size        int
+	idleTimeout time.Duration
+
+	slots      chan struct{}
+	idle       []idleSession
+	purgeTimer *time.Timer
+	mutexIdle  sync.Mutex
+
+	healthCheck  func(*Session) error
+	closeSession func(*Session)
+}
+
+type idleSession struct {
+	sess  *Session
+	since time.Time
+}
+
+func newSessionPool(size int, idleTimeout time.Duration) *sessionPool {
+	return &sessionPool{
+		size:        size,
+		idleTimeout: idleTimeout,
+		slots:       make(chan struct{}, size),
+		healthCheck: func(sess *Session) error {
+			return sess.gatherFacts()
+		},
+		closeSession: func(sess *Session) {
+			if err := sess.closeNetconf(sess.sleepSSHClosed); err != nil {
+				sess.logFile(fmt.Sprintf("[sessionPool] close err: %q", err))
+			} else {
+				sess.logFile("[sessionPool] session closed")
+			}
+		},
+	}
+}
+
+// acquire wait for a free slot in pool then return an idle session which passes the health check
+// or a new session generated with newSession.
+//
+// The session return in pool when it is closed.
+func (pool *sessionPool) acquire(
+	ctx context.Context, newSession func(context.Context) (*Session, error),
+) (
+	*Session, error,
+) {
+	select {
+	case pool.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for a free session in pool: %w", ctx.Err())
+	}
+
+	for {
+		sess := pool.popIdle()
+		if sess == nil {
+			break
+		}
+		if err := pool.healthCheck(sess); err != nil {
+			sess.logFile(fmt.Sprintf("[sessionPool] idle session failed health check: %s", err))
+			pool.closeSession(sess)
+
+			continue
+		}
+		sess.logFile("[sessionPool] idle session reused")
+		pool.setRelease(sess)
+
+		return sess, nil
+	}
+
+	sess, err := newSession(ctx)
+	if err != nil {
+		<-pool.slots
+
+		return nil, err
+	}
+	pool.setRelease(sess)
+
+	return sess, nil
+}
+
+// setRelease configure the session to return in pool (only once) when it is closed.
+func (pool *sessionPool) setRelease(sess *Session) {
+	var once sync.Once
+	sess.release = func() {
+		once.Do(func() {
+			pool.put(sess)
+		})
+	}
+}
+
+// popIdle close the expired idle sessions and return the last used idle session (or nil).
+func (pool *sessionPool) popIdle() *Session {
+	pool.mutexIdle.Lock()
+	expired := pool.takeExpired(time.Now())
+	var sess *Session
+	if last := len(pool.idle) - 1; last >= 0 {
+		sess = pool.idle[last].sess
+		pool.idle = pool.idle[:last]
+	}
+	pool.mutexIdle.Unlock()
+
+	for _, v := range expired {
+		pool.closeSession(v)
+	}
+
+	return sess
+}
+
+// put add the session in idle sessions and free its slot.
+func (pool *sessionPool) put(sess *Session) {
+	pool.mutexIdle.Lock()
+	pool.idle = append(pool.idle, idleSession{
+		sess:  sess,
+		since: time.Now(),
+	})
+	if pool.idleTimeout > 0 && pool.purgeTimer == nil {
+		pool.purgeTimer = time.AfterFunc(pool.idleTimeout, pool.purge)
+	}
+	pool.mutexIdle.Unlock()
+
+	<-pool.slots
+}
+
+// purge close the expired idle sessions in background
+// and schedule the next purge if there are still idle sessions.
+func (pool *sessionPool) purge() {
+	now := time.Now()
+	pool.mutexIdle.Lock()
+	expired := pool.takeExpired(now)
+	if len(pool.idle) > 0 {
+		pool.purgeTimer = time.AfterFunc(pool.idle[0].since.Add(pool.idleTimeout).Sub(now), pool.purge)
+	} else {
+		pool.purgeTimer = nil
+	}
+	pool.mutexIdle.Unlock()
+
+	for _, v := range expired {
+		pool.closeSession(v)
+	}
+}
+
+// takeExpired remove the expired sessions from idle sessions and return them.
+//
+// Idle sessions are sorted by age so expired sessions are at the start.
+// mutexIdle need to be locked.
+func (pool *sessionPool) takeExpired(now time.Time) []*Session {
+	if pool.idleTimeout <= 0 {
+		return nil
+	}
+	var expired []*Session
+	for len(pool.idle) > 0 && now.Sub(pool.idle[0].since) >= pool.idleTimeout {
+		expired = append(expired, pool.idle[0].sess)
+		pool.idle = pool.idle[1:]
+	}
+
+	return expired
+}
@@ -0,0 +1,177 @@
+package junos
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type sessionPoolTest struct {
+	pool    *sessionPool
+	mutex   sync.Mutex
+	created int
+	closed  []*Session
+	broken  map[*Session]bool
+}
+
+func newSessionPoolTest(size int, idleTimeout time.Duration) *sessionPoolTest {
+	test := &sessionPoolTest{
+		pool:   newSessionPool(size, idleTimeout),
+		broken: make(map[*Session]bool),
+	}
+	test.pool.healthCheck = func(sess *Session) error {
+		test.mutex.Lock()
+		defer test.mutex.Unlock()
+		if test.broken[sess] {
+			return errors.New("broken")
+		}
+
+		return nil
+	}
+	test.pool.closeSession = func(sess *Session) {
+		test.mutex.Lock()
+		defer test.mutex.Unlock()
+		test.closed = append(test.closed, sess)
+	}
+
+	return test
+}
+
+func (test *sessionPoolTest) newSession(_ context.Context) (*Session, error) {
+	test.mutex.Lock()
+	defer test.mutex.Unlock()
+	test.created++
+
+	return &Session{logFile: func(string) {}}, nil
+}
+
+func (test *sessionPoolTest) counts() (int, int) {
+	test.mutex.Lock()
+	defer test.mutex.Unlock()
+
+	return test.created, len(test.closed)
+}
+
+func TestSessionPoolReuse(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	test := newSessionPoolTest(2, 0)
+
+	sess1, err := test.pool.acquire(ctx, test.newSession)
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+	sess1.Close()
+	sess1.Close() // a second close must not return twice the session in pool
+	sess2, err := test.pool.acquire(ctx, test.newSession)
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+	if sess2 != sess1 {
+		t.Errorf("expected idle session to be reused")
+	}
+	sess3, err := test.pool.acquire(ctx, test.newSession)
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+	if sess3 == sess1 {
+		t.Errorf("expected a new session when idle session is in use")
+	}
+	if created, closed := test.counts(); created != 2 || closed != 0 {
+		t.Errorf("unexpected number of created (%d) or closed (%d) sessions", created, closed)
+	}
+}
+
+func TestSessionPoolBounded(t *testing.T) {
+	t.Parallel()
+
+	test := newSessionPoolTest(1, 0)
+
+	sess, err := test.pool.acquire(context.Background(), test.newSession)
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := test.pool.acquire(ctx, test.newSession); err == nil {
+		t.Errorf("expected error when pool is full but got none")
+	}
+
+	acquired := make(chan *Session)
+	go func() {
+		s, _ := test.pool.acquire(context.Background(), test.newSession)
+		acquired <- s
+	}()
+	sess.Close()
+	select {
+	case s := <-acquired:
+		if s != sess {
+			t.Errorf("expected the released session")
+		}
+	case <-time.After(time.Second):
+		t.Errorf("waiting session not acquired after release")
+	}
+}
+
+func TestSessionPoolHealthCheck(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	test := newSessionPoolTest(1, 0)
+
+	sess1, err := test.pool.acquire(ctx, test.newSession)
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+	sess1.Close()
+	test.mutex.Lock()
+	test.broken[sess1] = true
+	test.mutex.Unlock()
+
+	sess2, err := test.pool.acquire(ctx, test.newSession)
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+	if sess2 == sess1 {
+		t.Errorf("expected a new session when idle session failed health check")
+	}
+	if created, closed := test.counts(); created != 2 || closed != 1 {
+		t.Errorf("unexpected number of created (%d) or closed (%d) sessions", created, closed)
+	}
+}
+
+func TestSessionPoolIdleTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	test := newSessionPoolTest(2, 20*time.Millisecond)
+
+	sess1, err := test.pool.acquire(ctx, test.newSession)
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+	sess1.Close()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, closed := test.counts(); closed == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("idle session not closed after idle timeout")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	sess2, err := test.pool.acquire(ctx, test.newSession)
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+	if sess2 == sess1 {
+		t.Errorf("expected a new session after idle timeout")
+	}
+}
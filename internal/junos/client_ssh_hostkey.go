@@ -0,0 +1,203 @@
+package junos
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const sha256FingerprintPrefix = "SHA256:"
+
+// sshHostKeyOptions: options to verify the SSH host key of the device.
+type sshHostKeyOptions struct {
+	publicKeys     []ssh.PublicKey
+	fingerprints   []string
+	knownHostsFile string
+	trustFirstUse  bool
+}
+
+// mutex to read and append the known_hosts files
+// (a file can be shared by the options of clients of devices and jump hosts).
+var mutexKnownHostsFiles sync.Mutex
+
+// HostKeyError is returned when the SSH host key presented by the device is not trusted.
+type HostKeyError struct {
+	Host        string
+	Fingerprint string
+	Reason      string
+}
+
+func (e *HostKeyError) Error() string {
+	return fmt.Sprintf("SSH host key verification failed for %s (key %s): %s", e.Host, e.Fingerprint, e.Reason)
+}
+
+func (opts *sshHostKeyOptions) enabled() bool {
+	return len(opts.publicKeys) > 0 || len(opts.fingerprints) > 0 || opts.knownHostsFile != ""
+}
+
+// hostKeyCallback generate the ssh.HostKeyCallback with options for the hostname (<address>:<port>).
+//
+// The hostname is used instead of the hostname provided by the SSH handshake
+// (remote address of the connection) to be able to match names in known_hosts file.
+// Without any option, the host key is not verified.
+func (opts *sshHostKeyOptions) hostKeyCallback(hostname string) ssh.HostKeyCallback {
+	if opts == nil || !opts.enabled() {
+		return ssh.InsecureIgnoreHostKey()
+	}
+
+	return func(_ string, remote net.Addr, key ssh.PublicKey) error {
+		for _, v := range opts.publicKeys {
+			if v.Type() == key.Type() && bytes.Equal(v.Marshal(), key.Marshal()) {
+				return nil
+			}
+		}
+		for _, v := range opts.fingerprints {
+			if fingerprintMatch(v, key) {
+				return nil
+			}
+		}
+		if opts.knownHostsFile != "" {
+			return opts.checkKnownHosts(hostname, remote, key)
+		}
+
+		return &HostKeyError{
+			Host:        hostname,
+			Fingerprint: ssh.FingerprintSHA256(key),
+			Reason:      "key doesn't match any of trusted host keys or fingerprints",
+		}
+	}
+}
+
+// knownHostsCallback generate the ssh.HostKeyCallback for the hostname (<address>:<port>)
+// which only verify the host key with the known_hosts file.
+func (opts *sshHostKeyOptions) knownHostsCallback(hostname string) ssh.HostKeyCallback {
+	return func(_ string, remote net.Addr, key ssh.PublicKey) error {
+		return opts.checkKnownHosts(hostname, remote, key)
+	}
+}
+
+// checkKnownHosts verify the host key with the known_hosts file
+// and add the key to the file if it's the first use of host and trust on first use is enabled.
+func (opts *sshHostKeyOptions) checkKnownHosts(hostname string, remote net.Addr, key ssh.PublicKey) error {
+	mutexKnownHostsFiles.Lock()
+	defer mutexKnownHostsFiles.Unlock()
+
+	if _, err := os.Stat(opts.knownHostsFile); err != nil {
+		if !errors.Is(err, os.ErrNotExist) || !opts.trustFirstUse {
+			return fmt.Errorf("reading known_hosts file: %w", err)
+		}
+
+		return opts.appendKnownHosts(hostname, remote, key)
+	}
+	callback, err := knownhosts.New(opts.knownHostsFile)
+	if err != nil {
+		return fmt.Errorf("reading known_hosts file %q: %w", opts.knownHostsFile, err)
+	}
+	err = callback(hostname, remote, key)
+	if err == nil {
+		return nil
+	}
+
+	var keyErr *knownhosts.KeyError
+	if errors.As(err, &keyErr) {
+		if len(keyErr.Want) == 0 {
+			if opts.trustFirstUse {
+				return opts.appendKnownHosts(hostname, remote, key)
+			}
+
+			return &HostKeyError{
+				Host:        hostname,
+				Fingerprint: ssh.FingerprintSHA256(key),
+				Reason:      fmt.Sprintf("host is unknown in known_hosts file %q", opts.knownHostsFile),
+			}
+		}
+		want := make([]string, len(keyErr.Want))
+		for i, v := range keyErr.Want {
+			want[i] = fmt.Sprintf("%s (%s:%d)", ssh.FingerprintSHA256(v.Key), v.Filename, v.Line)
+		}
+
+		return &HostKeyError{
+			Host:        hostname,
+			Fingerprint: ssh.FingerprintSHA256(key),
+			Reason:      "key mismatch with known_hosts file, expected " + strings.Join(want, " or "),
+		}
+	}
+	var revokedErr *knownhosts.RevokedError
+	if errors.As(err, &revokedErr) {
+		return &HostKeyError{
+			Host:        hostname,
+			Fingerprint: ssh.FingerprintSHA256(key),
+			Reason:      fmt.Sprintf("key is revoked in known_hosts file %q", opts.knownHostsFile),
+		}
+	}
+
+	return err
+}
+
+func (opts *sshHostKeyOptions) appendKnownHosts(hostname string, remote net.Addr, key ssh.PublicKey) error {
+	addresses := []string{knownhosts.Normalize(hostname)}
+	if remote != nil {
+		if remoteAddr := knownhosts.Normalize(remote.String()); remoteAddr != addresses[0] {
+			addresses = append(addresses, remoteAddr)
+		}
+	}
+
+	dirFile := path.Dir(opts.knownHostsFile)
+	if _, err := os.Stat(dirFile); err != nil {
+		if err := os.MkdirAll(dirFile, os.FileMode(0o700)); err != nil {
+			return fmt.Errorf("creating parent directory of '%s': %w", opts.knownHostsFile, err)
+		}
+	}
+	f, err := os.OpenFile(opts.knownHostsFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(0o600))
+	if err != nil {
+		return fmt.Errorf("opening file '%s': %w", opts.knownHostsFile, err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(knownhosts.Line(addresses, key) + "\n"); err != nil {
+		return fmt.Errorf("writing in file '%s': %w", opts.knownHostsFile, err)
+	}
+
+	return nil
+}
+
+// fingerprintMatch check if key match the fingerprint
+// in OpenSSH format (SHA256:<base64>) or in SSHFP format (hexadecimal SHA-256).
+func fingerprintMatch(fingerprint string, key ssh.PublicKey) bool {
+	if strings.HasPrefix(fingerprint, sha256FingerprintPrefix) {
+		return strings.TrimRight(fingerprint, "=") == ssh.FingerprintSHA256(key)
+	}
+
+	sum := sha256.Sum256(key.Marshal())
+
+	return strings.EqualFold(strings.ReplaceAll(fingerprint, ":", ""), hex.EncodeToString(sum[:]))
+}
+
+func validateFingerprint(fingerprint string) error {
+	if v, ok := strings.CutPrefix(fingerprint, sha256FingerprintPrefix); ok {
+		if _, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(v, "=")); err != nil {
+			return fmt.Errorf("decoding base64 of fingerprint %q: %w", fingerprint, err)
+		}
+
+		return nil
+	}
+	b, err := hex.DecodeString(strings.ReplaceAll(fingerprint, ":", ""))
+	if err != nil {
+		return fmt.Errorf("decoding hexadecimal of fingerprint %q: %w", fingerprint, err)
+	}
+	if len(b) != sha256.Size {
+		return fmt.Errorf("fingerprint %q is not a SHA-256 hash", fingerprint)
+	}
+
+	return nil
+}
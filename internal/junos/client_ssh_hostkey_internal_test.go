@@ -0,0 +1,237 @@
+package junos
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"path"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func testHostKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %s", err)
+	}
+	key, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("converting key: %s", err)
+	}
+
+	return key
+}
+
+func TestSSHHostKeyCallback(t *testing.T) {
+	t.Parallel()
+
+	hostname := "192.0.2.1:830"
+	remote := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 830}
+	key := testHostKey(t)
+	otherKey := testHostKey(t)
+	sum := sha256.Sum256(key.Marshal())
+
+	type testCase struct {
+		opts        *sshHostKeyOptions
+		expectError bool
+	}
+	tests := map[string]testCase{
+		"no_option": {
+			opts:        &sshHostKeyOptions{},
+			expectError: false,
+		},
+		"public_key_valid": {
+			opts:        &sshHostKeyOptions{publicKeys: []ssh.PublicKey{otherKey, key}},
+			expectError: false,
+		},
+		"public_key_invalid": {
+			opts:        &sshHostKeyOptions{publicKeys: []ssh.PublicKey{otherKey}},
+			expectError: true,
+		},
+		"fingerprint_openssh_valid": {
+			opts:        &sshHostKeyOptions{fingerprints: []string{ssh.FingerprintSHA256(key)}},
+			expectError: false,
+		},
+		"fingerprint_sshfp_valid": {
+			opts:        &sshHostKeyOptions{fingerprints: []string{hex.EncodeToString(sum[:])}},
+			expectError: false,
+		},
+		"fingerprint_invalid": {
+			opts:        &sshHostKeyOptions{fingerprints: []string{ssh.FingerprintSHA256(otherKey)}},
+			expectError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := test.opts.hostKeyCallback(hostname)(hostname, remote, key)
+			if err == nil && test.expectError {
+				t.Errorf("expected error but got none")
+			}
+			if err != nil && !test.expectError {
+				t.Errorf("got unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestSSHHostKeyCallbackTrustOnFirstUse(t *testing.T) {
+	t.Parallel()
+
+	hostname := "192.0.2.1:830"
+	remote := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 830}
+	key := testHostKey(t)
+	otherKey := testHostKey(t)
+
+	opts := &sshHostKeyOptions{
+		knownHostsFile: path.Join(t.TempDir(), "known_hosts"),
+	}
+	var hostKeyErr *HostKeyError
+	if err := opts.hostKeyCallback(hostname)(hostname, remote, key); err == nil {
+		t.Errorf("expected error with missing known_hosts file but got none")
+	}
+
+	opts.trustFirstUse = true
+	if err := opts.hostKeyCallback(hostname)(hostname, remote, key); err != nil {
+		t.Errorf("got unexpected error on first use: %s", err)
+	}
+	if err := opts.hostKeyCallback(hostname)(hostname, remote, key); err != nil {
+		t.Errorf("got unexpected error after first use: %s", err)
+	}
+	if err := opts.hostKeyCallback(hostname)(hostname, remote, otherKey); !errors.As(err, &hostKeyErr) {
+		t.Errorf("expected host key error with other key but got: %v", err)
+	}
+	if err := opts.hostKeyCallback("192.0.2.2:830")(
+		"192.0.2.2:830", &net.TCPAddr{IP: net.ParseIP("192.0.2.2"), Port: 830}, otherKey,
+	); err != nil {
+		t.Errorf("got unexpected error on first use of other host: %s", err)
+	}
+}
+
+func TestSSHJumpHostKeyCallback(t *testing.T) {
+	t.Parallel()
+
+	hostname := "192.0.2.10:22"
+	remote := &net.TCPAddr{IP: net.ParseIP("192.0.2.10"), Port: 22}
+	deviceKey := testHostKey(t)
+	jumpHostKey := testHostKey(t)
+
+	// the host keys of device are not used for the jump host
+	clt, err := NewClient("192.0.2.1").WithSSHHostKeys([]string{string(ssh.MarshalAuthorizedKey(deviceKey))})
+	if err != nil {
+		t.Fatalf("adding host keys: %s", err)
+	}
+	if _, err := clt.WithSSHJumpHosts([]SSHJumpHost{{IP: "192.0.2.10", Port: 22}}); err != nil {
+		t.Fatalf("adding jump hosts: %s", err)
+	}
+	if _, err := clt.sshJumpHostKeyCallback(clt.junosSSHJumpHosts[0], hostname); err == nil {
+		t.Errorf("expected error without options for jump host but got none")
+	}
+
+	// the jump host has its own fingerprints
+	if _, err := clt.WithSSHJumpHosts([]SSHJumpHost{{
+		IP:                  "192.0.2.10",
+		Port:                22,
+		HostKeyFingerprints: []string{ssh.FingerprintSHA256(jumpHostKey)},
+	}}); err != nil {
+		t.Fatalf("adding jump hosts: %s", err)
+	}
+	callback, err := clt.sshJumpHostKeyCallback(clt.junosSSHJumpHosts[0], hostname)
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+	if err := callback(hostname, remote, jumpHostKey); err != nil {
+		t.Errorf("got unexpected error with key of jump host: %s", err)
+	}
+	if err := callback(hostname, remote, deviceKey); err == nil {
+		t.Errorf("expected error with key of device but got none")
+	}
+
+	// the jump host uses the known_hosts file of device
+	clt = NewClient("192.0.2.1").WithSSHKnownHostsFile(path.Join(t.TempDir(), "known_hosts")).WithSSHTrustOnFirstUse()
+	if _, err := clt.WithSSHJumpHosts([]SSHJumpHost{{IP: "192.0.2.10", Port: 22}}); err != nil {
+		t.Fatalf("adding jump hosts: %s", err)
+	}
+	callback, err = clt.sshJumpHostKeyCallback(clt.junosSSHJumpHosts[0], hostname)
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+	if err := callback(hostname, remote, jumpHostKey); err != nil {
+		t.Errorf("got unexpected error on first use: %s", err)
+	}
+	if err := callback(hostname, remote, deviceKey); err == nil {
+		t.Errorf("expected error with other key after first use but got none")
+	}
+
+	if _, err := NewClient("192.0.2.1").WithSSHJumpHosts([]SSHJumpHost{{
+		IP:                  "192.0.2.10",
+		HostKeyFingerprints: []string{"SHA256:!!"},
+	}}); err == nil {
+		t.Errorf("expected error with bad fingerprint but got none")
+	}
+}
+
+func TestSSHDeviceHostKeyCallback(t *testing.T) {
+	t.Parallel()
+
+	clientKey := testHostKey(t)
+	sw1Key := testHostKey(t)
+	sw2Key := testHostKey(t)
+
+	clt, err := NewClient("192.0.2.1").WithSSHHostKeys([]string{string(ssh.MarshalAuthorizedKey(clientKey))})
+	if err != nil {
+		t.Fatalf("adding host keys: %s", err)
+	}
+	if _, err := clt.WithDevices(map[string]Device{
+		"sw1": {IP: "192.0.2.11", HostKeys: []string{string(ssh.MarshalAuthorizedKey(sw1Key))}},
+		"sw2": {IP: "192.0.2.12", HostKeyFingerprints: []string{ssh.FingerprintSHA256(sw2Key)}},
+		"sw3": {IP: "192.0.2.13"},
+	}); err != nil {
+		t.Fatalf("adding devices: %s", err)
+	}
+	if _, err := NewClient("192.0.2.1").WithDevices(map[string]Device{
+		"sw1": {IP: "192.0.2.11", HostKeyFingerprints: []string{"bad"}},
+	}); err == nil {
+		t.Errorf("expected error with bad fingerprint of device but got none")
+	}
+
+	tests := map[string]struct {
+		device    string
+		key       ssh.PublicKey
+		expectErr bool
+	}{
+		"sw1_own_key":       {device: "sw1", key: sw1Key},
+		"sw1_client_key":    {device: "sw1", key: clientKey, expectErr: true},
+		"sw2_own_key":       {device: "sw2", key: sw2Key},
+		"sw2_other_key":     {device: "sw2", key: sw1Key, expectErr: true},
+		"sw3_inherited_key": {device: "sw3", key: clientKey},
+		"sw3_key_of_device": {device: "sw3", key: sw1Key, expectErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			deviceClt, err := clt.DeviceClient(test.device)
+			if err != nil {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+			hostname := net.JoinHostPort(deviceClt.junosIP, "830")
+			remote := &net.TCPAddr{IP: net.ParseIP(deviceClt.junosIP), Port: 830}
+			err = deviceClt.junosSSHHostKey.hostKeyCallback(hostname)(hostname, remote, test.key)
+			if test.expectErr && err == nil {
+				t.Errorf("expected error but got none")
+			}
+			if !test.expectErr && err != nil {
+				t.Errorf("got unexpected error: %s", err)
+			}
+		})
+	}
+}
@@ -26,6 +26,14 @@ const (
 	LoadConfigActionSet      = "set"
 	LoadConfigActionUpdate   = "update"
 
+	ConfigModeShared    = "shared"
+	ConfigModePrivate   = "private"
+	ConfigModeExclusive = "exclusive"
+
+	CommitSynchronizeAuto   = "auto"
+	CommitSynchronizeAlways = "always"
+	CommitSynchronizeNever  = "never"
+
 	RoutingInstancesWS = "routing-instances " // routing-instances word + space
 
 	RoutingOptionsWS = "routing-options "
@@ -50,24 +58,42 @@ const (
 	EnvPort                       = "JUNOS_PORT"
 	EnvUsername                   = "JUNOS_USERNAME"
 	EnvPassword                   = "JUNOS_PASSWORD"
+	EnvOTP                        = "JUNOS_OTP"
+	EnvOTPFile                    = "JUNOS_OTP_FILE"
 	EnvKeyPem                     = "JUNOS_KEYPEM"
 	EnvKeyFile                    = "JUNOS_KEYFILE"
 	EnvKeyPass                    = "JUNOS_KEYPASS"
+	EnvCertPem                    = "JUNOS_CERTPEM"
+	EnvCertFile                   = "JUNOS_CERTFILE"
 	EnvGroupInterfaceDelete       = "JUNOS_GROUP_INTERFACE_DELETE"
 	EnvNoDecodeSecrets            = "JUNOS_NO_DECODE_SECRETS"
 	EnvSleepShort                 = "JUNOS_SLEEP_SHORT"
 	EnvSleepLock                  = "JUNOS_SLEEP_LOCK"
 	EnvCommitConfirmed            = "JUNOS_COMMIT_CONFIRMED"
 	EnvCommitConfirmedWaitPercent = "JUNOS_COMMIT_CONFIRMED_WAIT_PERCENT"
+	EnvCommitConfirmedDeferred    = "JUNOS_COMMIT_CONFIRMED_DEFERRED"
 	EnvSleepSSHClosed             = "JUNOS_SLEEP_SSH_CLOSED"
 	EnvSSHTimeoutToEstablish      = "JUNOS_SSH_TIMEOUT_TO_ESTABLISH"
 	EnvSSHRetryToEstablish        = "JUNOS_SSH_RETRY_TO_ESTABLISH"
+	EnvSSHKnownHostsFile          = "JUNOS_SSH_KNOWN_HOSTS_FILE"
+	EnvSSHTrustOnFirstUse         = "JUNOS_SSH_TRUST_ON_FIRST_USE"
+	EnvProxy                      = "JUNOS_PROXY"
 	EnvFilePermission             = "JUNOS_FILE_PERMISSION"
 	EnvLogPath                    = "JUNOS_LOG_PATH"
 	EnvFakecreateSetfile          = "JUNOS_FAKECREATE_SETFILE"
 	EnvFakeupdateAlso             = "JUNOS_FAKEUPDATE_ALSO"
 	EnvFakedeleteAlso             = "JUNOS_FAKEDELETE_ALSO"
 	EnvSingleSession              = "JUNOS_SINGLE_SESSION"
+	EnvSessionPoolSize            = "JUNOS_SESSION_POOL_SIZE"
+	EnvSessionPoolIdleTimeout     = "JUNOS_SESSION_POOL_IDLE_TIMEOUT"
+	EnvBatchCommit                = "JUNOS_BATCH_COMMIT"
+	EnvBatchCommitWindow          = "JUNOS_BATCH_COMMIT_WINDOW"
+	EnvConfigMode                 = "JUNOS_CONFIG_MODE"
+	EnvCommitSynchronize          = "JUNOS_COMMIT_SYNCHRONIZE"
+	EnvPlanCommitCheck            = "JUNOS_PLAN_COMMIT_CHECK"
+	EnvPlanJunosDiff              = "JUNOS_PLAN_JUNOS_DIFF"
+	EnvNetconfRecordFile          = "JUNOS_NETCONF_RECORD_FILE"
+	EnvNetconfReplayFile          = "JUNOS_NETCONF_REPLAY_FILE"
 
 	DefaultInterfaceTestAcc        = "ge-0/0/3"
 	DefaultInterfaceTestAcc2       = "ge-0/0/4"
@@ -148,6 +148,24 @@ func (sess *Session) netconfConfigSet(cmd []string) (string, error) {
 }
 
 func (sess *Session) netconfConfigLoad(action, format, config string) (string, error) {
+	reply, err := sess.netconfConfigLoadReply(action, format, config)
+	if err != nil {
+		return "", err
+	}
+	if len(reply.Errors) > 0 {
+		var message strings.Builder
+		for _, m := range reply.Errors {
+			_, _ = message.WriteString(m.Message + "\n")
+		}
+
+		return message.String(), nil
+	}
+
+	return "", nil
+}
+
+// netconfConfigLoadReply load the configuration and return the reply with its errors and warnings.
+func (sess *Session) netconfConfigLoadReply(action, format, config string) (*netconf.RPCReply, error) {
 	var rawConfig string
 	switch {
 	case action == LoadConfigActionSet:
@@ -164,25 +182,25 @@ func (sess *Session) netconfConfigLoad(action, format, config string) (string, e
 
 	reply, err := sess.netconf.Exec(netconf.RawMethod(rawConfig))
 	if err != nil {
-		return "", fmt.Errorf("executing netconf load-configuration with action %q and format %q: %w", action, format, err)
+		return nil, fmt.Errorf("executing netconf load-configuration with action %q and format %q: %w", action, format, err)
 	}
-	if len(reply.Errors) > 0 {
-		var message strings.Builder
-		for _, m := range reply.Errors {
-			_, _ = message.WriteString(m.Message + "\n")
-		}
 
-		return message.String(), nil
-	}
-
-	return "", nil
+	return reply, nil
 }
 
-// netConfConfigLock locks the candidate configuration.
+// netConfConfigLock locks the candidate configuration
+// or opens the private candidate configuration with the private mode.
 func (sess *Session) netconfConfigLock() (bool, error) {
-	reply, err := sess.netconf.Exec(netconf.RawMethod(rpcLockCandidate))
+	rpc, rpcName := rpcLockCandidate, "lock-candidate"
+	switch sess.configMode {
+	case ConfigModePrivate:
+		rpc, rpcName = rpcOpenConfigurationPrivate, "open-configuration(private)"
+	case ConfigModeExclusive:
+		rpc, rpcName = rpcLockConfiguration, "lock-configuration"
+	}
+	reply, err := sess.netconf.Exec(netconf.RawMethod(rpc))
 	if err != nil {
-		return false, fmt.Errorf("executing netconf lock-candidate: %w", err)
+		return false, fmt.Errorf("executing netconf %s: %w", rpcName, err)
 	}
 	if len(reply.Errors) > 0 {
 		return false, nil
@@ -191,23 +209,33 @@ func (sess *Session) netconfConfigLock() (bool, error) {
 	return true, nil
 }
 
-// Unlock unlocks the candidate configuration.
+// Unlock unlocks the candidate configuration
+// or closes the private candidate configuration with the private mode.
+//
+// With the exclusive mode, the uncommitted changes are discarded before unlock
+// like when exiting a `configure exclusive`.
 func (sess *Session) netconfConfigUnlock() []error {
-	reply, err := sess.netconf.Exec(netconf.RawMethod(rpcUnlockCandidate))
+	rpc, rpcName := rpcUnlockCandidate, "unlock-candidate"
+	var errs []error
+	switch sess.configMode {
+	case ConfigModePrivate:
+		rpc, rpcName = rpcCloseConfiguration, "close-configuration"
+	case ConfigModeExclusive:
+		rpc, rpcName = rpcUnlockConfiguration, "unlock-configuration"
+		if err := sess.netconfExecRPC(rpcLoadConfigRollback0, "load-configuration(rollback 0)"); err != nil {
+			errs = append(errs, fmt.Errorf("config discard uncommitted changes: %w", err))
+		}
+	}
+	reply, err := sess.netconf.Exec(netconf.RawMethod(rpc))
 	if err != nil {
-		return []error{fmt.Errorf("executing netconf unlock-candidate: %w", err)}
+		return append(errs, fmt.Errorf("executing netconf %s: %w", rpcName, err))
 	}
 
-	if len(reply.Errors) > 0 {
-		errs := make([]error, len(reply.Errors))
-		for i, m := range reply.Errors {
-			errs[i] = errors.New("config unlock: " + m.Message)
-		}
-
-		return errs
+	for _, m := range reply.Errors {
+		errs = append(errs, errors.New("config unlock: "+m.Message))
 	}
 
-	return nil
+	return errs
 }
 
 func (sess *Session) netconfConfigGet(format string) (string, error) {
@@ -266,28 +294,41 @@ func (sess *Session) netconfConfigGet(format string) (string, error) {
 //
 // return potential warnings and/or error.
 func (sess *Session) netconfCommit(logMessage string) (_ []error, _ error) {
+	if sess.commitSynchronize {
+		reply, err := sess.netconf.Exec(netconf.RawMethod(fmt.Sprintf(rpcCommitConfigSynchronize, logMessage)))
+		if err != nil {
+			return nil, fmt.Errorf("executing netconf commit synchronize: %w", err)
+		}
+
+		return sess.readNetconfCommitReply(reply, "commit-configuration(synchronize)")
+	}
 	reply, err := sess.netconf.Exec(netconf.RawMethod(fmt.Sprintf(rpcCommitConfig, logMessage)))
 	if err != nil {
 		return nil, fmt.Errorf("executing netconf commit: %w", err)
 	}
 
-	return readNetconfCommitReply(reply, "commit-configuration")
+	return sess.readNetconfCommitReply(reply, "commit-configuration")
 }
 
-// netconfCommitConfirmed commits the configuration with confirmed option and confirmed timeout,
-// then wait percentage of timeout and send afterwards the confirmation with commit check.
+// netconfCommitAt commits the configuration at a specific time.
 //
 // return potential warnings and/or error.
-func (sess *Session) netconfCommitConfirmed(ctx context.Context, logMessage string) (warnings []error, _ error) {
-	reply, err := sess.netconf.Exec(
-		netconf.RawMethod(fmt.Sprintf(rpcCommitConfigConfirmed, logMessage, sess.commitConfirmedTimeout)),
-	)
+func (sess *Session) netconfCommitAt(atTime, logMessage string) (_ []error, _ error) {
+	reply, err := sess.netconf.Exec(netconf.RawMethod(fmt.Sprintf(rpcCommitConfigAtTime, atTime, logMessage)))
 	if err != nil {
-		return warnings, fmt.Errorf("executing netconf commit (confirmed %d): %w", sess.commitConfirmedTimeout, err)
+		return nil, fmt.Errorf("executing netconf commit at %q: %w", atTime, err)
 	}
 
-	replyWarns, err := readNetconfCommitReply(reply, "commit-configuration(confirmed)")
-	warnings = append(warnings, replyWarns...)
+	return sess.readNetconfCommitReply(reply, "commit-configuration(at-time)")
+}
+
+// netconfCommitConfirmed commits the configuration with confirmed option and confirmed timeout,
+// then wait percentage of timeout, run the health probes
+// and send afterwards the confirmation with commit check.
+//
+// return potential warnings and/or error.
+func (sess *Session) netconfCommitConfirmed(ctx context.Context, logMessage string) (warnings []error, _ error) {
+	warnings, err := sess.netconfCommitConfirmedWithoutConfirm(logMessage)
 	if err != nil {
 		return warnings, err
 	}
@@ -298,12 +339,11 @@ func (sess *Session) netconfCommitConfirmed(ctx context.Context, logMessage stri
 	case <-time.After(sess.commitConfirmedWait):
 	}
 
-	replyConfirm, err := sess.netconf.Exec(netconf.RawMethod(rpcCommitConfigCheck))
-	if err != nil {
-		return warnings, fmt.Errorf("executing netconf commit check (to confirm): %w", err)
+	if err := sess.runHealthProbes(ctx); err != nil {
+		return warnings, sess.errNotConfirmed(err)
 	}
 
-	replyWarns, err = readNetconfCommitReply(replyConfirm, "commit-configuration(check)")
+	replyWarns, err := sess.netconfCommitConfirm()
 	warnings = append(warnings, replyWarns...)
 	if err != nil {
 		return warnings, err
@@ -312,17 +352,63 @@ func (sess *Session) netconfCommitConfirmed(ctx context.Context, logMessage stri
 	return warnings, nil
 }
 
-func readNetconfCommitReply(reply *netconf.RPCReply, commitType string) (warnings []error, _ error) {
-	errs := make([]string, 0, len(reply.Errors))
+// netconfCommitConfirmedWithoutConfirm commits the configuration with confirmed option
+// and confirmed timeout without sending the confirmation.
+//
+// return potential warnings and/or error.
+func (sess *Session) netconfCommitConfirmedWithoutConfirm(logMessage string) (_ []error, _ error) {
+	if sess.commitSynchronize {
+		reply, err := sess.netconf.Exec(
+			netconf.RawMethod(fmt.Sprintf(rpcCommitConfigConfirmedSynchronize, logMessage, sess.commitConfirmedTimeout)),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("executing netconf commit synchronize (confirmed %d): %w",
+				sess.commitConfirmedTimeout, err)
+		}
+
+		return sess.readNetconfCommitReply(reply, "commit-configuration(synchronize confirmed)")
+	}
+	reply, err := sess.netconf.Exec(
+		netconf.RawMethod(fmt.Sprintf(rpcCommitConfigConfirmed, logMessage, sess.commitConfirmedTimeout)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("executing netconf commit (confirmed %d): %w", sess.commitConfirmedTimeout, err)
+	}
+
+	return sess.readNetconfCommitReply(reply, "commit-configuration(confirmed)")
+}
+
+// errNotConfirmed return the error when the commit is not confirmed after a failed health probe.
+func (sess *Session) errNotConfirmed(err error) error {
+	return fmt.Errorf("%w\ncommit not confirmed, "+
+		"the device rolls back the configuration at the end of the timeout of commit confirmed", err)
+}
+
+// netconfCommitConfirm send the confirmation of commits with confirmed option with commit check.
+//
+// return potential warnings and/or error.
+func (sess *Session) netconfCommitConfirm() (_ []error, _ error) {
+	reply, err := sess.netconf.Exec(netconf.RawMethod(rpcCommitConfigCheck))
+	if err != nil {
+		return nil, fmt.Errorf("executing netconf commit check (to confirm): %w", err)
+	}
+
+	return sess.readNetconfCommitReply(reply, "commit-configuration(check)")
+}
+
+// readNetconfCommitReply read the errors and warnings in reply of commit
+// with the results for each Routing Engine (or node of chassis cluster).
+func (sess *Session) readNetconfCommitReply(reply *netconf.RPCReply, commitType string) (warnings []error, _ error) {
+	var commitErr CommitError
 	for _, m := range reply.Errors {
 		if m.Severity == errorSeverity {
-			errs = append(errs, m.Error())
+			commitErr.RPCErrors = append(commitErr.RPCErrors, m)
 		} else {
 			warnings = append(warnings, errors.New(m.Error()))
 		}
 	}
-	if len(errs) > 0 {
-		return warnings, errors.New(strings.Join(errs, "\n"))
+	if len(commitErr.RPCErrors) > 0 {
+		return warnings, &commitErr
 	}
 
 	var result commitResults
@@ -331,26 +417,87 @@ func readNetconfCommitReply(reply *netconf.RPCReply, commitType string) (warning
 			return warnings, fmt.Errorf("unmarshaling xml reply %q of %s: %w", reply.Data, commitType, err)
 		}
 
-		errs = make([]string, 0, len(result.Errors))
 		for _, m := range result.Errors {
 			if m.Severity == errorSeverity {
-				errs = append(errs, m.Error())
+				commitErr.RPCErrors = append(commitErr.RPCErrors, m)
 			} else {
 				warnings = append(warnings, errors.New(m.Error()))
 			}
 		}
-		if len(errs) > 0 {
-			return warnings, errors.New(strings.Join(errs, "\n"))
+		for _, re := range result.RoutingEngines {
+			for _, m := range re.Errors {
+				if re.Name != "" {
+					m.Message = re.Name + ": " + strings.TrimSpace(m.Message)
+				}
+				if m.Severity == errorSeverity {
+					commitErr.RPCErrors = append(commitErr.RPCErrors, m)
+				} else {
+					warnings = append(warnings, errors.New(m.Error()))
+				}
+			}
+		}
+		warnings = append(warnings, sess.logCommitResultsRoutingEngines(result.RoutingEngines, commitType)...)
+		if len(commitErr.RPCErrors) > 0 {
+			return warnings, &commitErr
 		}
 	}
 
 	return warnings, nil
 }
 
+// netconfConfigCompareRollback0 returns the uncommitted changes in candidate configuration.
+func (sess *Session) netconfConfigCompareRollback0() (string, error) {
+	reply, err := sess.netconf.Exec(netconf.RawMethod(rpcGetConfigCompareRollback0))
+	if err != nil {
+		return "", fmt.Errorf("executing netconf get-configuration(compare rollback 0): %w", err)
+	}
+	if len(reply.Errors) > 0 {
+		errs := make([]string, len(reply.Errors))
+		for i, m := range reply.Errors {
+			errs[i] = m.Error()
+		}
+
+		return "", errors.New(strings.Join(errs, "\n"))
+	}
+	if !strings.Contains(reply.Data, "<configuration-information>") {
+		return "", nil
+	}
+	var output configCompareReply
+	if err := xml.Unmarshal([]byte(reply.Data), &output); err != nil {
+		return "", fmt.Errorf("unmarshaling xml reply of get-configuration(compare rollback 0): %w", err)
+	}
+
+	return strings.TrimSpace(output.Output), nil
+}
+
+// netconfExecRPC executes a rpc which doesn't return data.
+func (sess *Session) netconfExecRPC(rpc, rpcName string) error {
+	reply, err := sess.netconf.Exec(netconf.RawMethod(rpc))
+	if err != nil {
+		return fmt.Errorf("executing netconf %s: %w", rpcName, err)
+	}
+	if len(reply.Errors) > 0 {
+		errs := make([]string, 0, len(reply.Errors))
+		for _, m := range reply.Errors {
+			if m.Severity == errorSeverity {
+				errs = append(errs, m.Error())
+			}
+		}
+		if len(errs) > 0 {
+			return errors.New(strings.Join(errs, "\n"))
+		}
+	}
+
+	return nil
+}
+
 // Close disconnects our session to the device.
 func (sess *Session) closeNetconf(sleepClosed int) error {
 	_, err := sess.netconf.Exec(netconf.RawMethod(rpcCloseSession))
 	sess.netconf.Transport.Close()
+	if errAgent := sess.sshAgentConns.close(); errAgent != nil {
+		sess.logFile(fmt.Sprintf("[closeNetconf] closing connections to SSH agent: %s", errAgent))
+	}
 	if err != nil {
 		utils.Sleep(sleepClosed)
 
@@ -3,6 +3,7 @@ package junos
 import (
 	"encoding/xml"
 	"fmt"
+	"strings"
 
 	"github.com/jeremmfr/go-netconf/netconf"
 )
@@ -34,18 +35,43 @@ const (
 		"<log>%s</log>" +
 		"<confirmed/><confirm-timeout>%d</confirm-timeout>" +
 		"</commit-configuration>"
+	rpcCommitConfigSynchronize = "<commit-configuration>" +
+		"<synchronize/>" +
+		"<log>%s</log>" +
+		"</commit-configuration>"
+	rpcCommitConfigConfirmedSynchronize = "<commit-configuration>" +
+		"<synchronize/>" +
+		"<log>%s</log>" +
+		"<confirmed/><confirm-timeout>%d</confirm-timeout>" +
+		"</commit-configuration>"
+	rpcCommitConfigAtTime = "<commit-configuration>" +
+		"<at-time>%s</at-time>" +
+		"<log>%s</log>" +
+		"</commit-configuration>"
 	rpcCommitConfigCheck = "<commit-configuration>" +
 		"<check/>" +
 		"</commit-configuration>"
 
-	rpcLockCandidate   = "<lock><target><candidate/></target></lock>"
-	rpcUnlockCandidate = "<unlock><target><candidate/></target></unlock>"
+	rpcLockCandidate       = "<lock><target><candidate/></target></lock>"
+	rpcUnlockCandidate     = "<unlock><target><candidate/></target></unlock>"
+	rpcLockConfiguration   = "<lock-configuration/>"
+	rpcUnlockConfiguration = "<unlock-configuration/>"
 
 	rpcCloseSession = "<close-session/>"
 
+	rpcOpenConfigurationPrivate  = "<open-configuration><private/></open-configuration>"
+	rpcCloseConfiguration        = "<close-configuration/>"
+	rpcLoadConfigRollback0       = "<load-configuration rollback=\"0\"/>"
+	rpcLoadConfigRollback        = "<load-configuration rollback=\"%d\"/>"
+	rpcGetConfigCompareRollback0 = "<get-configuration compare=\"rollback\" rollback=\"0\" format=\"text\"/>"
+
 	rpcGetConfigurationCommitted            = "<get-configuration database=\"committed\" format=\"%s\"></get-configuration>"
 	rpcGetSystemInformation                 = "<get-system-information/>"
+	rpcGetRouteEngineInformation            = "<get-route-engine-information/>"
 	RPCGetChassisInventory                  = `<get-chassis-inventory></get-chassis-inventory>`
+	RPCClearSystemCommit                    = `<clear-system-commit/>`
+	RPCGetCommitInformation                 = `<get-commit-information/>`
+	RPCGetRollbackInformationCompare        = `<get-rollback-information><rollback>%d</rollback><compare>%d</compare></get-rollback-information>`
 	RPCGetInterfaceInformationInterfaceName = "<get-interface-information><interface-name>%s</interface-name></get-interface-information>"
 	RPCGetInterfacesInformationTerse        = `<get-interface-information><terse/></get-interface-information>`
 	RPCGetInterfaceInformationTerse         = `<get-interface-information>%s<terse/></get-interface-information>`
@@ -74,9 +100,37 @@ type commandXMLConfig struct {
 	Config string `xml:",innerxml"`
 }
 
+type configCompareReply struct {
+	XMLName xml.Name `xml:"configuration-information"`
+	Output  string   `xml:"configuration-output"`
+}
+
 type commitResults struct {
-	XMLName xml.Name           `xml:"commit-results"`
-	Errors  []netconf.RPCError `xml:"rpc-error"`
+	XMLName        xml.Name                     `xml:"commit-results"`
+	Errors         []netconf.RPCError           `xml:"rpc-error"`
+	RoutingEngines []commitResultsRoutingEngine `xml:"routing-engine"`
+}
+
+// commitResultsRoutingEngine: result of commit on a Routing Engine (or a node of chassis cluster).
+type commitResultsRoutingEngine struct {
+	Name               string             `xml:"name"`
+	CommitSuccess      *struct{}          `xml:"commit-success"`
+	CommitCheckSuccess *struct{}          `xml:"commit-check-success"`
+	Errors             []netconf.RPCError `xml:"rpc-error"`
+}
+
+// CommitError: errors returned by the device to a commit.
+type CommitError struct {
+	RPCErrors []netconf.RPCError
+}
+
+func (e *CommitError) Error() string {
+	errs := make([]string, len(e.RPCErrors))
+	for i, m := range e.RPCErrors {
+		errs[i] = m.Error()
+	}
+
+	return strings.Join(errs, "\n")
 }
 
 type RPCGetPhysicalInterfaceTerseReply struct {
@@ -148,6 +202,28 @@ type RPCGetChassisInventoryReply struct {
 	} `xml:"chassis"`
 }
 
+type RPCGetCommitInformationReply struct {
+	XMLName       xml.Name                                    `xml:"commit-information"`
+	CommitHistory []RPCGetCommitInformationReplyCommitHistory `xml:"commit-history"`
+}
+
+type RPCGetCommitInformationReplyCommitHistory struct {
+	SequenceNumber int    `xml:"sequence-number"`
+	User           string `xml:"user"`
+	Client         string `xml:"client"`
+	DateTime       struct {
+		Value   string `xml:",chardata"`
+		Seconds int64  `xml:"seconds,attr"`
+	} `xml:"date-time"`
+	Log     string `xml:"log"`
+	Comment string `xml:"comment"`
+}
+
+type RPCGetRollbackInformationReply struct {
+	XMLName             xml.Name `xml:"rollback-information"`
+	ConfigurationOutput string   `xml:"configuration-information>configuration-output"`
+}
+
 type RPCGetChassisInventoryReplyComponent struct {
 	Name         *string `xml:"name"`
 	Version      *string `xml:"version"`
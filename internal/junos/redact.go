@@ -0,0 +1,46 @@
+package junos
+
+import (
+	"regexp"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junossecret"
+)
+
+// RedactedSecret: the value which replaces the redacted secrets.
+const RedactedSecret = "redacted"
+
+var (
+	// secrets to redact:
+	// the quoted values after a keyword of secret (like the secrets sent in clear text)
+	// and the encrypted or hashed secrets ($9$, $1$, $5$, $6$, $8$).
+	secretKeywordRegexp = regexp.MustCompile(`\b((?:preauthentication-|client-)?secret` +
+		`|(?:authentication-|privacy-)?key` +
+		`|(?:authentication-|privacy-|encrypted-|simple-)?password|plain-text-password-value` +
+		`|ascii-text|hexadecimal) ("|&quot;|&#34;)(.*?)("|&quot;|&#34;)`)
+	secretEncryptedRegexp = regexp.MustCompile(`\$([15689])\$[^"&<\s]+`)
+)
+
+// RedactSecrets replace the secrets in a netconf message or in set lines by `redacted`
+// (encrypted with $9$ or with only the prefix of hash for the encrypted or hashed secrets)
+// to not write them in fixture files or in plan.
+func RedactSecrets(message string) string {
+	message = secretKeywordRegexp.ReplaceAllStringFunc(message, func(match string) string {
+		sub := secretKeywordRegexp.FindStringSubmatch(match)
+		if secretEncryptedRegexp.MatchString(sub[3]) {
+			// redacted with the encrypted secrets
+			return match
+		}
+
+		return sub[1] + " " + sub[2] + RedactedSecret + sub[4]
+	})
+
+	return secretEncryptedRegexp.ReplaceAllStringFunc(message, func(match string) string {
+		if match[1] == '9' {
+			encoded, _ := junossecret.Encode9(RedactedSecret)
+
+			return encoded
+		}
+
+		return match[:3] + RedactedSecret
+	})
+}
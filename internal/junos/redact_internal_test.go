@@ -0,0 +1,58 @@
+package junos
+
+import (
+	"testing"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junossecret"
+)
+
+func TestRedactSecrets(t *testing.T) {
+	t.Parallel()
+
+	redacted9, err := junossecret.Encode9(RedactedSecret)
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+	tests := map[string]struct {
+		message string
+		expect  string
+	}{
+		"clear_text_in_request": {
+			message: "<configuration-set>set protocols ospf area 0 interface ge-0/0/0 " +
+				"authentication simple-password \"pass word\"\n" +
+				"set security ike policy p1 pre-shared-key ascii-text \"psk\"</configuration-set>",
+			expect: "<configuration-set>set protocols ospf area 0 interface ge-0/0/0 " +
+				"authentication simple-password \"redacted\"\n" +
+				"set security ike policy p1 pre-shared-key ascii-text \"redacted\"</configuration-set>",
+		},
+		"encrypted_in_reply": {
+			message: "<configuration-output>set snmp v3 usm local-engine user u1 " +
+				"authentication-sha authentication-key &quot;$9$Vdw4ZHqfz39Ap&quot;\n" +
+				"set system login user u1 authentication encrypted-password &quot;$6$salt$hash.hash/&quot;" +
+				"</configuration-output>",
+			expect: "<configuration-output>set snmp v3 usm local-engine user u1 " +
+				"authentication-sha authentication-key &quot;" + redacted9 + "&quot;\n" +
+				"set system login user u1 authentication encrypted-password &quot;$6$redacted&quot;" +
+				"</configuration-output>",
+		},
+		"encrypted_in_xml": {
+			message: "<secret>$9$abc.DEF</secret>",
+			expect:  "<secret>" + redacted9 + "</secret>",
+		},
+		"without_secret": {
+			message: "<configuration-set>set system host-name \"router1\"\n" +
+				"set security ssh-known-hosts host h1 ecdsa-sha2-nistp256-key AAAA</configuration-set>",
+			expect: "<configuration-set>set system host-name \"router1\"\n" +
+				"set security ssh-known-hosts host h1 ecdsa-sha2-nistp256-key AAAA</configuration-set>",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if v := RedactSecrets(test.message); v != test.expect {
+				t.Errorf("got %q, expected %q", v, test.expect)
+			}
+		})
+	}
+}
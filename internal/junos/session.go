@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/jeremmfr/terraform-provider-junos/internal/tfdata"
@@ -18,6 +20,8 @@ import (
 	"golang.org/x/crypto/ssh"
 )
 
+var errOpenPrivateConfiguration = errors.New("opening private candidate configuration")
+
 // Session : store Junos device info and session.
 type Session struct {
 	SystemInformation      rpcSystemInformation
@@ -31,24 +35,47 @@ type Session struct {
 	sleepLock              int
 	commitConfirmedTimeout int
 	commitConfirmedWait    time.Duration
+	deferredCommitConfirm  *deferredCommitConfirm
+	deferredConfirmBegun   bool
+	healthProbes           []HealthProbe
+	healthProbeFailure     *healthProbeFailure
 	sleepSSHClosed         int
 	release                func()
 	reconnectNetconf       func(context.Context) (*Session, error)
+	configMode             string
+	commitSynchronize      bool
+	commitBatch            *commitBatch
+	commitBatchLoads       []commitBatchLoad
+	sshAgentConns          *sshAgentConns
+	configSnapshot         *configSnapshot
 }
 
 type sshAuthMethod struct {
-	Password       string
-	Username       string
-	PrivateKeyPEM  string
-	PrivateKeyFile string
-	Passphrase     string
-	Ciphers        []string
-	Timeout        int
+	Password        string
+	Username        string
+	PrivateKeyPEM   string
+	PrivateKeyFile  string
+	Passphrase      string
+	CertificatePEM  string
+	CertificateFile string
+	OTP             string
+	OTPFile         string
+	Ciphers         []string
+	Timeout         int
+
+	HostKeyCallback ssh.HostKeyCallback
+
+	// keep the connections to the SSH agent if not nil
+	agentConns *sshAgentConns
 }
 
 type openSSHOptions struct {
-	Retry   int
-	Timeout int
+	Retry     int
+	Timeout   int
+	JumpHosts []sshJumpHostOptions
+	Proxy     *url.URL
+	// record the netconf exchanges if not nil
+	Record func(netconfExchange) error
 }
 
 type sshOptions struct {
@@ -96,8 +123,13 @@ func netconfNewSessionWithConfig(
 toretry:
 	for retry > 0 {
 		retry--
-		conn, err := netDialer.DialContext(ctx, "tcp", host)
+		conn, err := sshOpts.dialContext(ctx, &netDialer, host)
 		if err != nil {
+			// don't retry if the host key of a jump host is not trusted
+			var hostKeyErr *HostKeyError
+			if errors.As(err, &hostKeyErr) {
+				return nil, fmt.Errorf("error connecting to %s: %w", host, err)
+			}
 			select {
 			case <-ctx.Done():
 				return nil, fmt.Errorf("error connecting to %s: %w", host, err)
@@ -117,6 +149,11 @@ toretry:
 		s, err := netconf.NewSSHSession(conn, sshOpts.ClientConfig)
 		if err != nil {
 			_ = conn.Close()
+			// don't retry if the host key is not trusted
+			var hostKeyErr *HostKeyError
+			if errors.As(err, &hostKeyErr) {
+				return nil, fmt.Errorf("initializing SSH session to %s: %w", host, err)
+			}
 			select {
 			case <-ctx.Done():
 				return nil, fmt.Errorf("initializing SSH session to %s: %w", host, err)
@@ -134,6 +171,14 @@ toretry:
 			}
 		}
 
+		if sshOpts.Record != nil {
+			s.Transport = &netconfRecordTransport{
+				Transport: s.Transport,
+				host:      host,
+				record:    sshOpts.Record,
+			}
+		}
+
 		return newSessionFromNetconf(s, conn.LocalAddr().String(), conn.RemoteAddr().String())
 	}
 	// this return can't happen
@@ -158,14 +203,20 @@ func newSessionFromNetconf(
 }
 
 // genSSHClientConfig is a wrapper function based around the auth method defined
-// (user/password or private key) which returns the SSH client configuration used to
-// connect.
+// (user/password, private key, certificate or keyboard-interactive) which returns
+// the SSH client configuration used to connect.
 func genSSHClientConfig(auth *sshAuthMethod) (*ssh.ClientConfig, error) {
 	configs := make([]*ssh.ClientConfig, 0)
 	configs = append(configs, &ssh.ClientConfig{})
 
 	// keys method
 	switch {
+	case len(auth.CertificatePEM) > 0 || len(auth.CertificateFile) > 0:
+		config, err := sshConfigCertificate(auth)
+		if err != nil {
+			return config, fmt.Errorf("creating new SSHConfig with certificate: %w", err)
+		}
+		configs = append(configs, config)
 	case len(auth.PrivateKeyPEM) > 0:
 		config, err := netconf.SSHConfigPubKeyPem(auth.Username, []byte(auth.PrivateKeyPEM), auth.Passphrase)
 		if err != nil {
@@ -179,9 +230,9 @@ func genSSHClientConfig(auth *sshAuthMethod) (*ssh.ClientConfig, error) {
 		}
 		configs = append(configs, config)
 	case os.Getenv("SSH_AUTH_SOCK") != "":
-		config, err := netconf.SSHConfigPubKeyAgent(auth.Username)
+		config, err := sshConfigAgent(auth)
 		if err != nil {
-			log.Printf("[WARN] communicating with SSH agent: %s", err.Error())
+			log.Printf("[WARN] %s", err.Error())
 		} else {
 			configs = append(configs, config)
 		}
@@ -190,12 +241,23 @@ func genSSHClientConfig(auth *sshAuthMethod) (*ssh.ClientConfig, error) {
 		config := netconf.SSHConfigPassword(auth.Username, auth.Password)
 		configs = append(configs, config)
 	}
+	if len(auth.Password) > 0 || len(auth.OTP) > 0 || len(auth.OTPFile) > 0 {
+		configs = append(configs, &ssh.ClientConfig{
+			User: auth.Username,
+			Auth: []ssh.AuthMethod{
+				ssh.KeyboardInteractive(auth.keyboardInteractiveChallenge),
+			},
+		})
+	}
 	if len(configs) == 1 {
 		return configs[0], errors.New("no credentials/keys available")
 	}
 	configs[0] = configs[1]
 	configs[0].Ciphers = auth.Ciphers
-	configs[0].HostKeyCallback = ssh.InsecureIgnoreHostKey()
+	configs[0].HostKeyCallback = auth.HostKeyCallback
+	if configs[0].HostKeyCallback == nil {
+		configs[0].HostKeyCallback = ssh.InsecureIgnoreHostKey()
+	}
 	for _, v := range configs[2:] {
 		configs[0].Auth = append(configs[0].Auth, v.Auth...)
 	}
@@ -210,6 +272,17 @@ func (sess *Session) HasNetconf() bool {
 
 // Command (show, execute) on Junos device via netconf.
 func (sess *Session) Command(ctx context.Context, cmd string) (string, error) {
+	if sess.configSnapshot != nil {
+		read, err := sess.configSnapshot.command(cmd)
+		sess.logFile(fmt.Sprintf("[Command] cmd: %q", cmd))
+		sess.logFile(fmt.Sprintf("[Command] read: %q", read))
+
+		return read, err
+	}
+	if sess.netconf == nil {
+		return "", errors.New("internal error: call Session.Command without netconf session")
+	}
+
 	var (
 		read string
 		err  error
@@ -233,6 +306,10 @@ func (sess *Session) Command(ctx context.Context, cmd string) (string, error) {
 
 // CommandXML send XML cmd on Junos device via netconf.
 func (sess *Session) CommandXML(ctx context.Context, cmd string) (string, error) {
+	if sess.netconf == nil {
+		return "", errors.New("internal error: call Session.CommandXML without netconf session")
+	}
+
 	var (
 		read string
 		err  error
@@ -257,7 +334,16 @@ func (sess *Session) CommandXML(ctx context.Context, cmd string) (string, error)
 // ConfigSet append candidate configuration with set/delete lines
 // on Junos device via netconf or in fake file if set.
 func (sess *Session) ConfigSet(ctx context.Context, cmd []string) error {
-	if sess.netconf != nil {
+	if sess.commitBatch != nil {
+		sess.commitBatchLoads = append(sess.commitBatchLoads, commitBatchLoad{
+			action: LoadConfigActionSet,
+			format: ConfigFormatText,
+			config: strings.Join(cmd, "\n"),
+		})
+		sess.logFile(fmt.Sprintf("[ConfigSet] cmd for batch commit: %q", cmd))
+
+		return nil
+	} else if sess.netconf != nil {
 		var (
 			message string
 			err     error
@@ -285,7 +371,7 @@ func (sess *Session) ConfigSet(ctx context.Context, cmd []string) error {
 }
 
 func (sess *Session) ConfigLoad(ctx context.Context, action, format, config string) error {
-	if sess.netconf == nil {
+	if sess.netconf == nil && sess.commitBatch == nil {
 		return errors.New("internal error: call Session.ConfigLoad without netconf session")
 	}
 
@@ -310,6 +396,20 @@ func (sess *Session) ConfigLoad(ctx context.Context, action, format, config stri
 		return errors.New("unknown format %q to load configuration")
 	}
 
+	if sess.commitBatch != nil {
+		if action == LoadConfigActionOverride {
+			return fmt.Errorf("action %q to load configuration not supported with batch commit", action)
+		}
+		sess.commitBatchLoads = append(sess.commitBatchLoads, commitBatchLoad{
+			action: action,
+			format: format,
+			config: config,
+		})
+		sess.logFile(fmt.Sprintf("[ConfigLoad] %s %s for batch commit", action, format))
+
+		return nil
+	}
+
 	var (
 		message string
 		err     error
@@ -330,8 +430,41 @@ func (sess *Session) ConfigLoad(ctx context.Context, action, format, config stri
 	return nil
 }
 
+// ConfigLoadRollback load the configuration of a previous commit (like `rollback <rollback>`)
+// in candidate configuration.
+func (sess *Session) ConfigLoadRollback(ctx context.Context, rollback int) error {
+	if sess.commitBatch != nil {
+		return errors.New("loading a rollback configuration is not possible with batch commit")
+	}
+	if sess.netconf == nil {
+		return errors.New("internal error: call Session.ConfigLoadRollback without netconf session")
+	}
+
+	var err error
+	sess.netconfFuncReconnectWrapper(ctx, func() error {
+		err = sess.netconfExecRPC(
+			fmt.Sprintf(rpcLoadConfigRollback, rollback),
+			fmt.Sprintf("load-configuration(rollback %d)", rollback),
+		)
+
+		return err
+	})
+	utils.SleepShort(sess.sleepShort)
+	sess.logFile(fmt.Sprintf("[ConfigLoadRollback] rollback %d", rollback))
+	if err != nil {
+		sess.logFile(fmt.Sprintf("[ConfigLoadRollback] err: %q", err))
+
+		return err
+	}
+
+	return nil
+}
+
 // ConfigGet: get committed configuration in desired format.
 func (sess *Session) ConfigGet(ctx context.Context, format string) (string, error) {
+	if sess.configSnapshot != nil {
+		return sess.configSnapshot.configGet(format)
+	}
 	if sess.netconf == nil {
 		return "", errors.New("internal error: call Session.ConfigGet without netconf session")
 	}
@@ -366,12 +499,26 @@ func (sess *Session) ConfigGet(ctx context.Context, format string) (string, erro
 	return output, nil
 }
 
-// ConfigLock lock candidate configuration and retry with sleep between when fail.
+// ConfigLock lock candidate configuration (or open the private candidate configuration
+// with the private mode) and retry with sleep between when fail.
+//
+// With batch commit, the changes are loaded in a private candidate configuration
+// so the lock is not necessary.
 func (sess *Session) ConfigLock(ctx context.Context) error {
+	if sess.commitBatch != nil {
+		return nil
+	}
+	if sess.netconf == nil {
+		return errors.New("internal error: call Session.ConfigLock without netconf session")
+	}
+	sess.beginDeferredCommitConfirm()
 	for {
 		select {
 		case <-ctx.Done():
 			sess.logFile("[ConfigLock] lock aborted")
+			if _, err := sess.endDeferredCommitConfirm(ctx, false); err != nil {
+				sess.logFile(fmt.Sprintf("[ConfigLock] confirm pending commits err: %q", err))
+			}
 
 			return errors.New("candidate configuration lock attempt aborted")
 		default:
@@ -384,7 +531,7 @@ func (sess *Session) ConfigLock(ctx context.Context) error {
 			})
 
 			if locked {
-				sess.logFile("[ConfigLock] config locked")
+				sess.logFile(fmt.Sprintf("[ConfigLock] config locked (%s mode)", sess.configMode))
 				utils.SleepShort(sess.sleepShort)
 
 				return nil
@@ -397,15 +544,34 @@ func (sess *Session) ConfigLock(ctx context.Context) error {
 
 // ConfigUnlock unlock candidate configuration.
 func (sess *Session) ConfigUnlock(ctx context.Context) []error {
+	if sess.commitBatch != nil {
+		// drop changes not submitted to batch commit
+		sess.commitBatchLoads = nil
+
+		return nil
+	}
+	if sess.netconf == nil {
+		return []error{errors.New("internal error: call Session.ConfigUnlock without netconf session")}
+	}
 	var errs []error
+	if sess.deferredConfirmBegun {
+		// resource action ended without commit
+		warnings, err := sess.endDeferredCommitConfirm(ctx, false)
+		errs = append(errs, warnings...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("confirming pending commits with 'confirmed' option: %w", err))
+		}
+	}
+	var unlockErrs []error
 	sess.netconfFuncReconnectWrapper(ctx, func() error {
-		errs = sess.netconfConfigUnlock()
-		if len(errs) > 0 {
-			return errs[0]
+		unlockErrs = sess.netconfConfigUnlock()
+		if len(unlockErrs) > 0 {
+			return unlockErrs[0]
 		}
 
 		return nil
 	})
+	errs = append(errs, unlockErrs...)
 
 	sess.logFile("[ConfigUnlock] config unlocked")
 	utils.SleepShort(sess.sleepShort)
@@ -413,9 +579,147 @@ func (sess *Session) ConfigUnlock(ctx context.Context) []error {
 	return errs
 }
 
+// ConfigDiscard discard the uncommitted changes in candidate configuration
+// and return them (in compare format with the committed configuration).
+//
+// With batch commit, the changes not yet submitted are dropped and returned.
+func (sess *Session) ConfigDiscard(ctx context.Context) (string, error) {
+	if sess.commitBatch != nil {
+		changes := make([]string, 0, len(sess.commitBatchLoads))
+		for _, load := range sess.commitBatchLoads {
+			changes = append(changes, load.config)
+		}
+		sess.commitBatchLoads = nil
+
+		return strings.Join(changes, "\n"), nil
+	}
+	if sess.netconf == nil {
+		return "", nil
+	}
+
+	var (
+		changes string
+		err     error
+	)
+	sess.netconfFuncReconnectWrapper(ctx, func() error {
+		changes, err = sess.netconfConfigCompareRollback0()
+
+		return err
+	})
+	if err != nil {
+		sess.logFile(fmt.Sprintf("[ConfigDiscard] compare err: %q", err))
+	} else if changes == "" {
+		return "", nil
+	}
+	if discardErr := sess.netconfExecRPC(rpcLoadConfigRollback0, "load-configuration(rollback 0)"); discardErr != nil {
+		sess.logFile(fmt.Sprintf("[ConfigDiscard] err: %q", discardErr))
+
+		return changes, discardErr
+	}
+	sess.logFile(fmt.Sprintf("[ConfigDiscard] changes discarded: %q", changes))
+	utils.SleepShort(sess.sleepShort)
+	if err != nil {
+		return "", fmt.Errorf("changes discarded but reading them before failed: %w", err)
+	}
+
+	return changes, nil
+}
+
+// ConfigCommitCheck load the set/delete lines in a private candidate configuration,
+// check the commit (without activating the configuration)
+// then close the private candidate configuration to discard the lines.
+func (sess *Session) ConfigCommitCheck(ctx context.Context, lines []string) (warnings []error, err error) {
+	if sess.netconf == nil {
+		return nil, errors.New("internal error: call Session.ConfigCommitCheck without netconf session")
+	}
+
+	if err := sess.netconfExecRPC(rpcOpenConfigurationPrivate, "open-configuration(private)"); err != nil {
+		return nil, fmt.Errorf("%w: %w", errOpenPrivateConfiguration, err)
+	}
+	defer func() {
+		if err := sess.netconfExecRPC(rpcCloseConfiguration, "close-configuration"); err != nil {
+			sess.logFile(fmt.Sprintf("[ConfigCommitCheck] close-configuration err: %q", err))
+		}
+	}()
+
+	message, err := sess.netconfConfigSet(lines)
+	sess.logFile(fmt.Sprintf("[ConfigCommitCheck] cmd: %q", lines))
+	sess.logFile(fmt.Sprintf("[ConfigCommitCheck] message: %q", message))
+	if err != nil {
+		return nil, err
+	}
+	reply, err := sess.netconf.Exec(netconf.RawMethod(rpcCommitConfigCheck))
+	if err != nil {
+		err = fmt.Errorf("executing netconf commit check: %w", err)
+	} else {
+		warnings, err = sess.readNetconfCommitReply(reply, "commit-configuration(check)")
+	}
+	if err != nil {
+		sess.logFile(fmt.Sprintf("[ConfigCommitCheck] err: %q", err))
+	}
+
+	return warnings, err
+}
+
+// beginDeferredCommitConfirm record the start of a resource action which can commit
+// for the deferred confirmation of commits with 'confirmed' option.
+func (sess *Session) beginDeferredCommitConfirm() {
+	if sess.commitConfirmedTimeout == 0 || sess.deferredCommitConfirm == nil || sess.deferredConfirmBegun {
+		return
+	}
+	sess.deferredCommitConfirm.begin()
+	sess.deferredConfirmBegun = true
+}
+
+// endDeferredCommitConfirm record the end of a resource action (with a commit or not)
+// for the deferred confirmation of commits with 'confirmed' option
+// and confirm the pending commits if it's the last resource action in progress.
+func (sess *Session) endDeferredCommitConfirm(ctx context.Context, committed bool) ([]error, error) {
+	if sess.commitConfirmedTimeout == 0 || sess.deferredCommitConfirm == nil {
+		return nil, nil
+	}
+	begun := sess.deferredConfirmBegun
+	sess.deferredConfirmBegun = false
+
+	return sess.deferredCommitConfirm.end(ctx, sess, begun, committed)
+}
+
 // CommitConf commit the configuration with message via netconf.
+//
+// With batch commit, the changes are submitted to the next commit of batch.
 func (sess *Session) CommitConf(ctx context.Context, logMessage string) (warnings []error, err error) {
-	if sess.commitConfirmedTimeout > 0 {
+	if err := sess.healthProbeFailure.failed(); err != nil {
+		return nil, err
+	}
+	if sess.commitBatch != nil {
+		loads := sess.commitBatchLoads
+		sess.commitBatchLoads = nil
+		sess.logFile(fmt.Sprintf("[CommitConf] submit to batch commit %q", logMessage))
+		warnings, err = sess.commitBatch.submit(ctx, loads, logMessage)
+		if err != nil {
+			sess.logFile(fmt.Sprintf("[CommitConf] batch commit error: %q", err))
+		}
+
+		return warnings, err
+	}
+	if sess.netconf == nil {
+		return nil, errors.New("internal error: call Session.CommitConf without netconf session")
+	}
+	switch {
+	case sess.commitConfirmedTimeout > 0 && sess.deferredCommitConfirm != nil:
+		sess.logFile(fmt.Sprintf(
+			"[CommitConf] commit confirmed %d (deferred confirmation) %q",
+			sess.commitConfirmedTimeout, logMessage,
+		))
+		sess.netconfFuncReconnectWrapper(ctx, func() error {
+			warnings, err = sess.netconfCommitConfirmedWithoutConfirm(logMessage)
+
+			return err
+		})
+		confirmWarnings, errConfirm := sess.endDeferredCommitConfirm(ctx, err == nil)
+		warnings = append(warnings, confirmWarnings...)
+		err = errors.Join(err, errConfirm)
+	case sess.commitConfirmedTimeout > 0:
 		sess.logFile(fmt.Sprintf(
 			"[CommitConf] commit confirmed %d (wait %s) %q",
 			sess.commitConfirmedTimeout, sess.commitConfirmedWait, logMessage,
@@ -425,7 +729,7 @@ func (sess *Session) CommitConf(ctx context.Context, logMessage string) (warning
 
 			return err
 		})
-	} else {
+	default:
 		sess.logFile(fmt.Sprintf("[CommitConf] commit %q", logMessage))
 		sess.netconfFuncReconnectWrapper(ctx, func() error {
 			warnings, err = sess.netconfCommit(logMessage)
@@ -448,7 +752,45 @@ func (sess *Session) CommitConf(ctx context.Context, logMessage string) (warning
 	return warnings, nil
 }
 
+// CommitConfAt check the configuration and schedule its commit at atTime
+// (like `commit at <atTime>`) with message via netconf.
+//
+// The commit options of client (confirmed, synchronize, batch) are not used.
+func (sess *Session) CommitConfAt(ctx context.Context, atTime, logMessage string) (warnings []error, err error) {
+	if sess.commitBatch != nil {
+		return nil, errors.New("commit at a specific time is not possible with batch commit")
+	}
+	if err := sess.healthProbeFailure.failed(); err != nil {
+		return nil, err
+	}
+	if sess.netconf == nil {
+		return nil, errors.New("internal error: call Session.CommitConfAt without netconf session")
+	}
+	sess.logFile(fmt.Sprintf("[CommitConfAt] commit at %q %q", atTime, logMessage))
+	sess.netconfFuncReconnectWrapper(ctx, func() error {
+		warnings, err = sess.netconfCommitAt(atTime, logMessage)
+
+		return err
+	})
+	utils.SleepShort(sess.sleepShort)
+	for _, w := range warnings {
+		sess.logFile(fmt.Sprintf("[CommitConfAt] commit warning: %q", w))
+	}
+	if err != nil {
+		sess.logFile(fmt.Sprintf("[CommitConfAt] commit error: %q", err))
+
+		return warnings, err
+	}
+
+	return warnings, nil
+}
+
 func (sess *Session) Close() {
+	if sess.deferredConfirmBegun {
+		if _, err := sess.endDeferredCommitConfirm(context.Background(), false); err != nil {
+			sess.logFile(fmt.Sprintf("[Close] confirm pending commits err: %q", err))
+		}
+	}
 	if sess.release != nil {
 		sess.release()
 
@@ -0,0 +1,96 @@
+package junos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// sshJumpHostOptions: SSH server to go through (with its client configuration)
+// to reach the next jump host or the device.
+type sshJumpHostOptions struct {
+	Host         string
+	ClientConfig *ssh.ClientConfig
+}
+
+// jumpHostConn: connection to the device tunneled through the SSH clients of jump hosts.
+type jumpHostConn struct {
+	net.Conn
+
+	jumpClients []*ssh.Client
+}
+
+// Close the connection to the device then the SSH clients of jump hosts in reverse order.
+func (c *jumpHostConn) Close() error {
+	errs := []error{c.Conn.Close()}
+	for i := len(c.jumpClients) - 1; i >= 0; i-- {
+		errs = append(errs, c.jumpClients[i].Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// dialContext connects to the host directly
+// or through the chain of jump hosts if there are any
+// (the first hop through the proxy if there is one).
+func (openSSH *openSSHOptions) dialContext(
+	ctx context.Context, netDialer *net.Dialer, host string,
+) (
+	net.Conn, error,
+) {
+	if len(openSSH.JumpHosts) == 0 {
+		return openSSH.dialFirstHop(ctx, netDialer, host)
+	}
+
+	conn, err := openSSH.dialFirstHop(ctx, netDialer, openSSH.JumpHosts[0].Host)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to jump host %s: %w", openSSH.JumpHosts[0].Host, err)
+	}
+	jumpClients := make([]*ssh.Client, 0, len(openSSH.JumpHosts))
+	closeJumpClients := func() {
+		for i := len(jumpClients) - 1; i >= 0; i-- {
+			_ = jumpClients[i].Close()
+		}
+	}
+	for i, jumpHost := range openSSH.JumpHosts {
+		sshConn, chans, reqs, err := ssh.NewClientConn(conn, jumpHost.Host, jumpHost.ClientConfig)
+		if err != nil {
+			_ = conn.Close()
+			closeJumpClients()
+
+			return nil, fmt.Errorf("initializing SSH connection to jump host %s: %w", jumpHost.Host, err)
+		}
+		jumpClient := ssh.NewClient(sshConn, chans, reqs)
+		jumpClients = append(jumpClients, jumpClient)
+
+		nextHost := host
+		if i+1 < len(openSSH.JumpHosts) {
+			nextHost = openSSH.JumpHosts[i+1].Host
+		}
+		conn, err = dialThroughJumpHost(ctx, jumpClient, nextHost, openSSH.Timeout)
+		if err != nil {
+			closeJumpClients()
+
+			return nil, fmt.Errorf("connecting to %s through jump host %s: %w", nextHost, jumpHost.Host, err)
+		}
+	}
+
+	return &jumpHostConn{
+		Conn:        conn,
+		jumpClients: jumpClients,
+	}, nil
+}
+
+func dialThroughJumpHost(ctx context.Context, jumpClient *ssh.Client, host string, timeout int) (net.Conn, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+		defer cancel()
+	}
+
+	return jumpClient.DialContext(ctx, "tcp", host)
+}
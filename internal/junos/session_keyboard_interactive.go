@@ -0,0 +1,61 @@
+package junos
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// otpPromptRegexp: keyboard-interactive prompts to answer with the one-time password.
+var otpPromptRegexp = regexp.MustCompile(`(?i)(otp|one[- ]time|token|passcode|verification|code)`)
+
+// keyboardInteractiveChallenge answer to keyboard-interactive prompts of the SSH server
+// (like RADIUS/TACACS+ login on Junos device):
+// prompts which look like a request of one-time password are answered with the OTP,
+// other hidden prompts are answered with the password.
+func (auth *sshAuthMethod) keyboardInteractiveChallenge(
+	_, _ string, questions []string, echos []bool,
+) (
+	[]string, error,
+) {
+	answers := make([]string, len(questions))
+	for i, question := range questions {
+		switch {
+		case otpPromptRegexp.MatchString(question):
+			otp, err := auth.readOTP()
+			if err != nil {
+				return nil, fmt.Errorf("answering keyboard-interactive prompt %q: %w", question, err)
+			}
+			answers[i] = otp
+		case !echos[i]:
+			answers[i] = auth.Password
+		default:
+			return nil, fmt.Errorf("unsupported keyboard-interactive prompt %q", question)
+		}
+	}
+
+	return answers, nil
+}
+
+// readOTP read the one-time password in file (at each call to be able to use a file updated
+// by an external tool) or use the static value.
+func (auth *sshAuthMethod) readOTP() (string, error) {
+	if auth.OTPFile != "" {
+		otp, err := os.ReadFile(auth.OTPFile)
+		if err != nil {
+			return "", fmt.Errorf("reading OTP file: %w", err)
+		}
+		if v := strings.TrimSpace(string(otp)); v != "" {
+			return v, nil
+		}
+
+		return "", fmt.Errorf("OTP file %q is empty", auth.OTPFile)
+	}
+	if auth.OTP != "" {
+		return auth.OTP, nil
+	}
+
+	return "", errors.New("one-time password requested but not configured")
+}
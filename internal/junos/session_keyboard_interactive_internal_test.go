@@ -0,0 +1,80 @@
+package junos
+
+import (
+	"os"
+	"path"
+	"slices"
+	"testing"
+)
+
+func TestSSHKeyboardInteractiveChallenge(t *testing.T) {
+	t.Parallel()
+
+	otpFile := path.Join(t.TempDir(), "otp")
+	if err := os.WriteFile(otpFile, []byte("654321\n"), 0o600); err != nil {
+		t.Fatalf("writing OTP file: %s", err)
+	}
+
+	type testCase struct {
+		auth          *sshAuthMethod
+		questions     []string
+		echos         []bool
+		expectAnswers []string
+		expectError   bool
+	}
+	tests := map[string]testCase{
+		"password": {
+			auth:          &sshAuthMethod{Password: "pass"},
+			questions:     []string{"Password:"},
+			echos:         []bool{false},
+			expectAnswers: []string{"pass"},
+		},
+		"password_and_otp": {
+			auth:          &sshAuthMethod{Password: "pass", OTP: "123456"},
+			questions:     []string{"Password:", "Enter PASSCODE:"},
+			echos:         []bool{false, false},
+			expectAnswers: []string{"pass", "123456"},
+		},
+		"otp_file": {
+			auth:          &sshAuthMethod{Password: "pass", OTP: "123456", OTPFile: otpFile},
+			questions:     []string{"One-time password:"},
+			echos:         []bool{false},
+			expectAnswers: []string{"654321"},
+		},
+		"otp_missing": {
+			auth:        &sshAuthMethod{Password: "pass"},
+			questions:   []string{"Verification code:"},
+			echos:       []bool{true},
+			expectError: true,
+		},
+		"unsupported_prompt": {
+			auth:        &sshAuthMethod{Password: "pass"},
+			questions:   []string{"Login reason:"},
+			echos:       []bool{true},
+			expectError: true,
+		},
+		"no_question": {
+			auth:          &sshAuthMethod{Password: "pass"},
+			questions:     []string{},
+			echos:         []bool{},
+			expectAnswers: []string{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			answers, err := test.auth.keyboardInteractiveChallenge("", "", test.questions, test.echos)
+			if err == nil && test.expectError {
+				t.Errorf("expected error but got none")
+			}
+			if err != nil && !test.expectError {
+				t.Errorf("got unexpected error: %s", err)
+			}
+			if !test.expectError && !slices.Equal(answers, test.expectAnswers) {
+				t.Errorf("got unexpected answers %q, want %q", answers, test.expectAnswers)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package junos
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+const (
+	proxySchemeSOCKS5  = "socks5"
+	proxySchemeSOCKS5H = "socks5h"
+	proxySchemeHTTP    = "http"
+)
+
+// validateProxyURL parse the proxy URL and check its scheme.
+func validateProxyURL(proxyURL string) (*url.URL, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing proxy URL: %w", err)
+	}
+	switch u.Scheme {
+	case proxySchemeSOCKS5, proxySchemeSOCKS5H, proxySchemeHTTP:
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q for proxy URL, need to be %s, %s or %s",
+			u.Scheme, proxySchemeSOCKS5, proxySchemeSOCKS5H, proxySchemeHTTP)
+	}
+	if u.Hostname() == "" {
+		return nil, errors.New("missing host in proxy URL")
+	}
+
+	return u, nil
+}
+
+// dialFirstHop connects to the host (device or first jump host)
+// directly or through the proxy if there is one.
+func (openSSH *openSSHOptions) dialFirstHop(
+	ctx context.Context, netDialer *net.Dialer, host string,
+) (
+	net.Conn, error,
+) {
+	if openSSH.Proxy == nil {
+		return netDialer.DialContext(ctx, "tcp", host)
+	}
+
+	switch openSSH.Proxy.Scheme {
+	case proxySchemeHTTP:
+		return dialHTTPConnect(ctx, netDialer, openSSH.Proxy, host)
+	default:
+		dialer, err := proxy.FromURL(openSSH.Proxy, netDialer)
+		if err != nil {
+			return nil, fmt.Errorf("preparing proxy %s: %w", openSSH.Proxy.Redacted(), err)
+		}
+		contextDialer, ok := dialer.(proxy.ContextDialer)
+		if !ok {
+			return nil, fmt.Errorf("proxy %s doesn't support dialing with context", openSSH.Proxy.Redacted())
+		}
+		conn, err := contextDialer.DialContext(ctx, "tcp", host)
+		if err != nil {
+			return nil, fmt.Errorf("connecting to %s through proxy %s: %w", host, openSSH.Proxy.Redacted(), err)
+		}
+
+		return conn, nil
+	}
+}
+
+// bufferedConn: connection with bytes already read from the connection in a buffer.
+type bufferedConn struct {
+	net.Conn
+
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// dialHTTPConnect connects to the host through a HTTP proxy with the CONNECT method.
+func dialHTTPConnect(
+	ctx context.Context, netDialer *net.Dialer, proxyURL *url.URL, host string,
+) (
+	net.Conn, error,
+) {
+	proxyHost := proxyURL.Host
+	if proxyURL.Port() == "" {
+		proxyHost = net.JoinHostPort(proxyURL.Hostname(), "80")
+	}
+	conn, err := netDialer.DialContext(ctx, "tcp", proxyHost)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to proxy %s: %w", proxyURL.Redacted(), err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	} else if netDialer.Timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(netDialer.Timeout))
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: host},
+		Host:   host,
+		Header: make(http.Header),
+	}
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		req.Header.Set("Proxy-Authorization", "Basic "+base64.StdEncoding.EncodeToString(
+			[]byte(proxyURL.User.Username()+":"+password),
+		))
+	}
+	if err := req.Write(conn); err != nil {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("sending CONNECT request to proxy %s: %w", proxyURL.Redacted(), err)
+	}
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("reading CONNECT response from proxy %s: %w", proxyURL.Redacted(), err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("connecting to %s through proxy %s: %s", host, proxyURL.Redacted(), resp.Status)
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	if reader.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, reader: reader}, nil
+	}
+
+	return conn, nil
+}
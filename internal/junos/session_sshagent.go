@@ -0,0 +1,70 @@
+package junos
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// sshAgentConns: connections to the SSH agent opened for the authentication methods
+// of the SSH client configurations of a session (and its jump hosts).
+//
+// The keys of SSH agent sign during the SSH handshakes (including the reconnections of session),
+// so the connections stay open until the session is closed.
+type sshAgentConns struct {
+	conns []net.Conn
+	mutex sync.Mutex
+}
+
+// dial open a new connection to the SSH agent and keep it to close it later.
+func (agentConns *sshAgentConns) dial() (net.Conn, error) {
+	conn, err := net.Dial("unix", os.Getenv("SSH_AUTH_SOCK"))
+	if err != nil {
+		return nil, fmt.Errorf("communicating with SSH agent: %w", err)
+	}
+	if agentConns != nil {
+		agentConns.mutex.Lock()
+		agentConns.conns = append(agentConns.conns, conn)
+		agentConns.mutex.Unlock()
+	}
+
+	return conn, nil
+}
+
+// close all the connections opened to the SSH agent.
+func (agentConns *sshAgentConns) close() error {
+	if agentConns == nil {
+		return nil
+	}
+	agentConns.mutex.Lock()
+	defer agentConns.mutex.Unlock()
+
+	errs := make([]error, len(agentConns.conns))
+	for i, conn := range agentConns.conns {
+		errs[i] = conn.Close()
+	}
+	agentConns.conns = nil
+
+	return errors.Join(errs...)
+}
+
+// sshConfigAgent generate the SSH client configuration
+// to authenticate with the keys provided by the SSH agent.
+func sshConfigAgent(auth *sshAuthMethod) (*ssh.ClientConfig, error) {
+	conn, err := auth.agentConns.dial()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ssh.ClientConfig{
+		User: auth.Username,
+		Auth: []ssh.AuthMethod{
+			ssh.PublicKeysCallback(agent.NewClient(conn).Signers),
+		},
+	}, nil
+}
@@ -0,0 +1,77 @@
+package junos
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"io"
+	"net"
+	"path"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+func TestSSHAgentConnsClose(t *testing.T) {
+	socket := path.Join(t.TempDir(), "agent.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("listening on agent socket: %s", err)
+	}
+	defer listener.Close()
+	t.Setenv("SSH_AUTH_SOCK", socket)
+
+	userPub, userKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generating user key: %s", err)
+	}
+	keyring := agent.NewKeyring()
+	if err := keyring.Add(agent.AddedKey{PrivateKey: userKey}); err != nil {
+		t.Fatalf("adding key to agent: %s", err)
+	}
+	served := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			served <- err
+
+			return
+		}
+		defer conn.Close()
+		served <- agent.ServeAgent(keyring, conn)
+	}()
+
+	userSSHPub, err := ssh.NewPublicKey(userPub)
+	if err != nil {
+		t.Fatalf("converting user key: %s", err)
+	}
+	agentConns := &sshAgentConns{}
+	signer, err := sshAgentSignerForCertificate(&ssh.Certificate{Key: userSSHPub}, agentConns)
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+	if _, err := signer.Sign(rand.Reader, []byte("data")); err != nil {
+		t.Errorf("got unexpected error on sign: %s", err)
+	}
+	if len(agentConns.conns) != 1 {
+		t.Errorf("got %d connections to agent, want 1", len(agentConns.conns))
+	}
+
+	// the agent sees the end of connection when the connections are closed
+	if err := agentConns.close(); err != nil {
+		t.Errorf("got unexpected error on close: %s", err)
+	}
+	select {
+	case err := <-served:
+		if err != nil && !errors.Is(err, io.EOF) {
+			t.Errorf("got unexpected error in agent: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("connection to agent not closed")
+	}
+	if len(agentConns.conns) != 0 {
+		t.Errorf("got %d connections to agent after close, want 0", len(agentConns.conns))
+	}
+}
@@ -0,0 +1,129 @@
+package junos
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// sshConfigCertificate generate the SSH client configuration
+// to authenticate with an OpenSSH user certificate and its private key
+// (in PEM, in file or provided by a SSH agent).
+func sshConfigCertificate(auth *sshAuthMethod) (*ssh.ClientConfig, error) {
+	cert, err := parseSSHCertificate(auth)
+	if err != nil {
+		return nil, err
+	}
+
+	var signer ssh.Signer
+	switch {
+	case len(auth.PrivateKeyPEM) > 0:
+		signer, err = parseSSHPrivateKey([]byte(auth.PrivateKeyPEM), auth.Passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("parsing PEM private key: %w", err)
+		}
+	case len(auth.PrivateKeyFile) > 0:
+		keyBytes, err := os.ReadFile(auth.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading private key file %q: %w", auth.PrivateKeyFile, err)
+		}
+		signer, err = parseSSHPrivateKey(keyBytes, auth.Passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("parsing private key file %q: %w", auth.PrivateKeyFile, err)
+		}
+	case os.Getenv("SSH_AUTH_SOCK") != "":
+		signer, err = sshAgentSignerForCertificate(cert, auth.agentConns)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("no private key available for SSH certificate")
+	}
+
+	certSigner, err := ssh.NewCertSigner(cert, signer)
+	if err != nil {
+		return nil, fmt.Errorf("associating SSH certificate with private key: %w", err)
+	}
+
+	return &ssh.ClientConfig{
+		User: auth.Username,
+		Auth: []ssh.AuthMethod{
+			ssh.PublicKeys(certSigner),
+		},
+	}, nil
+}
+
+// parseSSHCertificate read the OpenSSH user certificate
+// (in authorized_keys format like *-cert.pub files) and check its validity period.
+func parseSSHCertificate(auth *sshAuthMethod) (*ssh.Certificate, error) {
+	certBytes := []byte(auth.CertificatePEM)
+	if len(certBytes) == 0 {
+		var err error
+		certBytes, err = os.ReadFile(auth.CertificateFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading SSH certificate file %q: %w", auth.CertificateFile, err)
+		}
+	}
+	pubKey, _, _, _, err := ssh.ParseAuthorizedKey(certBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing SSH certificate: %w", err)
+	}
+	cert, ok := pubKey.(*ssh.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("SSH certificate is a public key (%s) and not an OpenSSH certificate", pubKey.Type())
+	}
+	if cert.CertType != ssh.UserCert {
+		return nil, errors.New("SSH certificate is not a user certificate")
+	}
+	now := uint64(time.Now().Unix())
+	if now < cert.ValidAfter {
+		return nil, fmt.Errorf("SSH certificate is not yet valid (valid after %s)",
+			time.Unix(int64(cert.ValidAfter), 0).UTC().Format(time.RFC3339))
+	}
+	if cert.ValidBefore != ssh.CertTimeInfinity && now >= cert.ValidBefore {
+		return nil, fmt.Errorf("SSH certificate has expired (valid before %s)",
+			time.Unix(int64(cert.ValidBefore), 0).UTC().Format(time.RFC3339))
+	}
+
+	return cert, nil
+}
+
+func parseSSHPrivateKey(key []byte, passphrase string) (ssh.Signer, error) {
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		var passphraseMissingError *ssh.PassphraseMissingError
+		if errors.As(err, &passphraseMissingError) && passphrase != "" {
+			return ssh.ParsePrivateKeyWithPassphrase(key, []byte(passphrase))
+		}
+
+		return nil, err
+	}
+
+	return signer, nil
+}
+
+// sshAgentSignerForCertificate search the key of the certificate in keys provided by the SSH agent.
+//
+// The connection to the SSH agent is kept in agentConns to be closed with the session.
+func sshAgentSignerForCertificate(cert *ssh.Certificate, agentConns *sshAgentConns) (ssh.Signer, error) {
+	conn, err := agentConns.dial()
+	if err != nil {
+		return nil, err
+	}
+	signers, err := agent.NewClient(conn).Signers()
+	if err != nil {
+		return nil, fmt.Errorf("listing keys of SSH agent: %w", err)
+	}
+	for _, signer := range signers {
+		if bytes.Equal(signer.PublicKey().Marshal(), cert.Key.Marshal()) {
+			return signer, nil
+		}
+	}
+
+	return nil, errors.New("private key of SSH certificate not found in SSH agent")
+}
@@ -0,0 +1,123 @@
+package junos
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSSHConfigCertificate(t *testing.T) {
+	t.Parallel()
+
+	_, caKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generating CA key: %s", err)
+	}
+	caSigner, err := ssh.NewSignerFromKey(caKey)
+	if err != nil {
+		t.Fatalf("converting CA key: %s", err)
+	}
+	userPub, userKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generating user key: %s", err)
+	}
+	userSSHPub, err := ssh.NewPublicKey(userPub)
+	if err != nil {
+		t.Fatalf("converting user key: %s", err)
+	}
+	userKeyPEM, err := ssh.MarshalPrivateKey(userKey, "")
+	if err != nil {
+		t.Fatalf("marshaling user key: %s", err)
+	}
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generating other key: %s", err)
+	}
+	otherKeyPEM, err := ssh.MarshalPrivateKey(otherKey, "")
+	if err != nil {
+		t.Fatalf("marshaling other key: %s", err)
+	}
+
+	signCert := func(certType uint32, validAfter, validBefore time.Time) string {
+		t.Helper()
+
+		cert := &ssh.Certificate{
+			Key:             userSSHPub,
+			CertType:        certType,
+			ValidPrincipals: []string{"netconf"},
+			ValidAfter:      uint64(validAfter.Unix()),
+			ValidBefore:     uint64(validBefore.Unix()),
+		}
+		if err := cert.SignCert(rand.Reader, caSigner); err != nil {
+			t.Fatalf("signing certificate: %s", err)
+		}
+
+		return string(ssh.MarshalAuthorizedKey(cert))
+	}
+	now := time.Now()
+
+	type testCase struct {
+		auth        *sshAuthMethod
+		expectError bool
+	}
+	tests := map[string]testCase{
+		"valid": {
+			auth: &sshAuthMethod{
+				Username:       "netconf",
+				PrivateKeyPEM:  string(pem.EncodeToMemory(userKeyPEM)),
+				CertificatePEM: signCert(ssh.UserCert, now.Add(-time.Minute), now.Add(time.Hour)),
+			},
+			expectError: false,
+		},
+		"expired": {
+			auth: &sshAuthMethod{
+				Username:       "netconf",
+				PrivateKeyPEM:  string(pem.EncodeToMemory(userKeyPEM)),
+				CertificatePEM: signCert(ssh.UserCert, now.Add(-time.Hour), now.Add(-time.Minute)),
+			},
+			expectError: true,
+		},
+		"host_certificate": {
+			auth: &sshAuthMethod{
+				Username:       "netconf",
+				PrivateKeyPEM:  string(pem.EncodeToMemory(userKeyPEM)),
+				CertificatePEM: signCert(ssh.HostCert, now.Add(-time.Minute), now.Add(time.Hour)),
+			},
+			expectError: true,
+		},
+		"other_private_key": {
+			auth: &sshAuthMethod{
+				Username:       "netconf",
+				PrivateKeyPEM:  string(pem.EncodeToMemory(otherKeyPEM)),
+				CertificatePEM: signCert(ssh.UserCert, now.Add(-time.Minute), now.Add(time.Hour)),
+			},
+			expectError: true,
+		},
+		"public_key": {
+			auth: &sshAuthMethod{
+				Username:       "netconf",
+				PrivateKeyPEM:  string(pem.EncodeToMemory(userKeyPEM)),
+				CertificatePEM: string(ssh.MarshalAuthorizedKey(userSSHPub)),
+			},
+			expectError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := sshConfigCertificate(test.auth)
+			if err == nil && test.expectError {
+				t.Errorf("expected error but got none")
+			}
+			if err != nil && !test.expectError {
+				t.Errorf("got unexpected error: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package junos
+
+import (
+	"testing"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junostest"
+)
+
+// newSimulatorClient start a simulator of Junos device (stopped at the end of test)
+// and return it with a client to connect to it.
+func newSimulatorClient(t *testing.T) (*junostest.Server, *Client) {
+	t.Helper()
+
+	srv := junostest.NewTestServer(t)
+
+	return srv, NewClient(srv.IP()).
+		WithPort(srv.Port()).
+		WithUserName("test").
+		WithPassword("test").
+		WithSleepShort(0).
+		WithSleepLock(0)
+}
@@ -0,0 +1,122 @@
+package junossecret_test
+
+import (
+	"testing"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junossecret"
+)
+
+func TestEncode9(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"Simple":  "testPassWord",
+		"OneChar": "a",
+		"Long":    "a long secret with spaces & symbols !#$%^*()_+-=[]{};:,.<>/?~",
+	}
+
+	for name, secret := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			encoded, err := junossecret.Encode9(secret)
+			if err != nil {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+			if again, _ := junossecret.Encode9(secret); again != encoded {
+				t.Errorf("got different encoded secret for same secret: %q, %q", encoded, again)
+			}
+			decoded, err := junossecret.Decode9(encoded)
+			if err != nil {
+				t.Fatalf("got unexpected error on decode %q: %s", encoded, err)
+			}
+			if decoded != secret {
+				t.Errorf("got unexpected decoded secret %q from %q, want %q", decoded, encoded, secret)
+			}
+		})
+	}
+
+	if _, err := junossecret.Encode9(""); err == nil {
+		t.Errorf("got no error with empty secret")
+	}
+	if _, err := junossecret.Encode9("sécrét"); err == nil {
+		t.Errorf("got no error with character out of range")
+	}
+}
+
+func TestDecode9(t *testing.T) {
+	t.Parallel()
+
+	decoded, err := junossecret.Decode9("$9$1HFIyKXxdsgJ-VH.Pfn6lKMXdsZUi5Qnikfz")
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+	if decoded != "testPassWord" {
+		t.Errorf("got unexpected decoded secret %q", decoded)
+	}
+
+	if _, err := junossecret.Decode9("$1$abc"); err == nil {
+		t.Errorf("got no error with secret without prefix")
+	}
+}
+
+func TestHashSHA512(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		password    string
+		salt        string
+		expectHash  string
+		expectError bool
+	}
+
+	tests := map[string]testCase{
+		"Valid": {
+			password:   "Hello world!",
+			salt:       "saltstring",
+			expectHash: "$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1",
+		},
+		"LongPassword": {
+			password: "we have a short salt string but not a short password",
+			salt:     "short",
+			expectHash: "$6$short$" +
+				"qmfj2meTBr5G2EAGIJ4vjX7RpefsD4JzpEyTAeEUJdzdxlBS6pe8gdMHm5zFftaFSj/2p2bjBwyVS9ZhWpLZt.",
+		},
+		"EmptySalt": {
+			password:    "password",
+			salt:        "",
+			expectError: true,
+		},
+		"TooLongSalt": {
+			password:    "password",
+			salt:        "abcdefghijklmnopq",
+			expectError: true,
+		},
+		"InvalidSalt": {
+			password:    "password",
+			salt:        "ab$cd",
+			expectError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			hash, err := junossecret.HashSHA512(test.password, test.salt)
+			if test.expectError {
+				if err == nil {
+					t.Errorf("got no error, want error")
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+			if hash != test.expectHash {
+				t.Errorf("got unexpected hash %q, want %q", hash, test.expectHash)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Package junossecret provides the encoding and hashing of secrets in Junos configuration
+// without connection to a device.
+package junossecret
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/jeremmfr/junosdecode"
+)
+
+const secret9Family0, secret9Family1, secret9Family2, secret9Family3 = "QzF3n6/9CAtpu0O",
+	"B1IREhcSyrleKvMW8LXx",
+	"7N-dVbwsY2g4oaJZGUDj",
+	"iHkq.mPf5T"
+
+// secret9Alpha: the characters of an encoded secret ($9$) in the order of their number.
+const secret9Alpha = secret9Family0 + secret9Family1 + secret9Family2 + secret9Family3
+
+// secret9Encoding: the weights of gaps between characters to encode a character of secret
+// (by position of character in secret, repeated).
+var secret9Encoding = [][]int{
+	{1, 4, 32},
+	{1, 16, 32},
+	{1, 8, 32},
+	{1, 64},
+	{1, 32},
+	{1, 4, 16, 128},
+	{1, 32, 64},
+}
+
+// ErrEmptySecret is returned when there is no secret to encode.
+var ErrEmptySecret = errors.New("no secret to encode")
+
+// Encode9 encode a secret like the Junos encrypted secret ($9$).
+//
+// The salt and the random characters of the encoded secret are derived from the secret
+// so that the same secret always gives the same encoded secret.
+func Encode9(secret string) (string, error) {
+	if secret == "" {
+		return "", ErrEmptySecret
+	}
+	seed := sha256.Sum256([]byte(secret))
+
+	var encoded strings.Builder
+	encoded.WriteString(junosdecode.MagicPrefix)
+	salt := secret9Alpha[int(seed[0])%len(secret9Alpha)]
+	encoded.WriteByte(salt)
+	for i := range secret9Extra(salt) {
+		encoded.WriteByte(secret9Alpha[int(seed[1+i])%len(secret9Alpha)])
+	}
+	prev := salt
+	for pos, r := range []rune(secret) {
+		if r > unicode.MaxASCII {
+			return "", fmt.Errorf("non-ASCII character %q at position %d can't be encoded", r, pos)
+		}
+		for _, gap := range secret9Gaps(int(r), secret9Encoding[pos%len(secret9Encoding)]) {
+			prev = secret9Alpha[(gap+strings.IndexByte(secret9Alpha, prev)+1)%len(secret9Alpha)]
+			encoded.WriteByte(prev)
+		}
+	}
+
+	return encoded.String(), nil
+}
+
+// Decode9 decode a Junos encrypted secret ($9$).
+func Decode9(encoded string) (string, error) {
+	if !strings.HasPrefix(encoded, junosdecode.MagicPrefix) {
+		return "", fmt.Errorf("secret doesn't start with %q", junosdecode.MagicPrefix)
+	}
+
+	return junosdecode.Decode(encoded)
+}
+
+// secret9Extra return the number of random characters after the salt.
+func secret9Extra(salt byte) int {
+	switch {
+	case strings.IndexByte(secret9Family0, salt) != -1:
+		return 3
+	case strings.IndexByte(secret9Family1, salt) != -1:
+		return 2
+	case strings.IndexByte(secret9Family2, salt) != -1:
+		return 1
+	}
+
+	return 0
+}
+
+// secret9Gaps return the gaps between characters which encode the number of a character
+// with the weights of encoding.
+func secret9Gaps(num int, encoding []int) []int {
+	gaps := make([]int, len(encoding))
+	for i := len(encoding) - 1; i >= 0; i-- {
+		gaps[i] = num / encoding[i]
+		num %= encoding[i]
+	}
+
+	return gaps
+}
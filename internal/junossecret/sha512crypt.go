@@ -0,0 +1,120 @@
+package junossecret
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"strings"
+)
+
+const (
+	// SHA512CryptPrefix: prefix of a password hashed with SHA-512 crypt.
+	SHA512CryptPrefix = "$6$"
+	// SHA512CryptSaltMaxLength: maximum length of salt for SHA-512 crypt.
+	SHA512CryptSaltMaxLength = 16
+
+	sha512CryptRounds   = 5000
+	sha512CryptAlphabet = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// sha512CryptPermutation: order of bytes of final digest by group of 3 bytes to encode it.
+var sha512CryptPermutation = [][3]int{
+	{0, 21, 42}, {22, 43, 1}, {44, 2, 23}, {3, 24, 45}, {25, 46, 4},
+	{47, 5, 26}, {6, 27, 48}, {28, 49, 7}, {50, 8, 29}, {9, 30, 51},
+	{31, 52, 10}, {53, 11, 32}, {12, 33, 54}, {34, 55, 13}, {56, 14, 35},
+	{15, 36, 57}, {37, 58, 16}, {59, 17, 38}, {18, 39, 60}, {40, 61, 19},
+	{62, 20, 41},
+}
+
+// HashSHA512 hash a password with SHA-512 crypt ($6$) and a salt
+// like the encrypted-password of Junos login users.
+func HashSHA512(password, salt string) (string, error) {
+	if salt == "" || len(salt) > SHA512CryptSaltMaxLength {
+		return "", fmt.Errorf("salt need to have 1 to %d characters", SHA512CryptSaltMaxLength)
+	}
+	for _, r := range salt {
+		if !strings.ContainsRune(sha512CryptAlphabet, r) {
+			return "", fmt.Errorf("character %q in salt isn't in %q", r, sha512CryptAlphabet)
+		}
+	}
+	pass, slt := []byte(password), []byte(salt)
+
+	digestB := sha512.New()
+	digestB.Write(pass)
+	digestB.Write(slt)
+	digestB.Write(pass)
+	sumB := digestB.Sum(nil)
+
+	digestA := sha512.New()
+	digestA.Write(pass)
+	digestA.Write(slt)
+	digestA.Write(sha512CryptRepeat(sumB, len(pass)))
+	for i := len(pass); i > 0; i >>= 1 {
+		if i&1 != 0 {
+			digestA.Write(sumB)
+		} else {
+			digestA.Write(pass)
+		}
+	}
+	sumA := digestA.Sum(nil)
+
+	digestP := sha512.New()
+	for range len(pass) {
+		digestP.Write(pass)
+	}
+	seqP := sha512CryptRepeat(digestP.Sum(nil), len(pass))
+
+	digestS := sha512.New()
+	for range 16 + int(sumA[0]) {
+		digestS.Write(slt)
+	}
+	seqS := sha512CryptRepeat(digestS.Sum(nil), len(slt))
+
+	sumC := sumA
+	for i := range sha512CryptRounds {
+		digestC := sha512.New()
+		if i&1 != 0 {
+			digestC.Write(seqP)
+		} else {
+			digestC.Write(sumC)
+		}
+		if i%3 != 0 {
+			digestC.Write(seqS)
+		}
+		if i%7 != 0 {
+			digestC.Write(seqP)
+		}
+		if i&1 != 0 {
+			digestC.Write(sumC)
+		} else {
+			digestC.Write(seqP)
+		}
+		sumC = digestC.Sum(nil)
+	}
+
+	var hash strings.Builder
+	hash.WriteString(SHA512CryptPrefix + salt + "$")
+	for _, v := range sha512CryptPermutation {
+		sha512CryptEncode(&hash, int(sumC[v[0]])<<16|int(sumC[v[1]])<<8|int(sumC[v[2]]), 4)
+	}
+	sha512CryptEncode(&hash, int(sumC[63]), 2)
+
+	return hash.String(), nil
+}
+
+// sha512CryptRepeat return the bytes of sum repeated to have the length.
+func sha512CryptRepeat(sum []byte, length int) []byte {
+	seq := make([]byte, 0, length)
+	for len(seq) < length {
+		seq = append(seq, sum[:min(len(sum), length-len(seq))]...)
+	}
+
+	return seq
+}
+
+// sha512CryptEncode write the characters of the bits of value (from the lowest).
+func sha512CryptEncode(hash *strings.Builder, value, chars int) {
+	for range chars {
+		hash.WriteByte(sha512CryptAlphabet[value&0x3f])
+		value >>= 6
+	}
+}
@@ -0,0 +1,226 @@
+package junostest
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/utils"
+)
+
+const (
+	maxRollback = 49
+
+	lockNone      = ""
+	lockShared    = "shared"
+	lockExclusive = "exclusive"
+)
+
+// Commit: a commit in history of device.
+type Commit struct {
+	User   string
+	Client string
+	Log    string
+	Time   time.Time
+}
+
+type revision struct {
+	lines  []string
+	commit Commit
+}
+
+// device: the configuration databases and the commit history.
+//
+// The configuration is a list of set lines in the order of their addition.
+type device struct {
+	// revisions of committed configuration, from the current one (rollback 0)
+	revisions []revision
+	candidate []string
+
+	lockOwner int
+	lockMode  string
+
+	// error message returned by the commits (except the commit checks)
+	commitError string
+	// error message returned by the commit checks
+	commitCheckError string
+
+	lastSessionID int
+	mutex         sync.Mutex
+}
+
+func newDevice() *device {
+	return &device{
+		revisions: []revision{{}},
+	}
+}
+
+func (d *device) loadConfig(lines []string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	config := make([]string, 0, len(lines))
+	for _, line := range lines {
+		config, _ = applyLine(config, line)
+	}
+	d.revisions = []revision{{lines: config}}
+	d.candidate = slices.Clone(config)
+}
+
+func (d *device) config() []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return slices.Clone(d.revisions[0].lines)
+}
+
+func (d *device) setCommitError(message string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.commitError = message
+}
+
+func (d *device) setCommitCheckError(message string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.commitCheckError = message
+}
+
+func (d *device) candidateConfig() []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return slices.Clone(d.candidate)
+}
+
+func (d *device) commitHistory() []Commit {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	commits := make([]Commit, 0, len(d.revisions))
+	for _, rev := range d.revisions {
+		if rev.commit.Time.IsZero() {
+			continue
+		}
+		commits = append(commits, rev.commit)
+	}
+
+	return commits
+}
+
+// commit replace the committed configuration with lines and add a revision in history.
+//
+// d.mutex need to be locked.
+func (d *device) commit(lines []string, commit Commit) {
+	d.revisions = slices.Insert(d.revisions, 0, revision{
+		lines:  slices.Clone(lines),
+		commit: commit,
+	})
+	if len(d.revisions) > maxRollback+1 {
+		d.revisions = d.revisions[:maxRollback+1]
+	}
+}
+
+// applyLine apply a set/delete/activate/deactivate line to config
+// and return the new config and if the line has modified it.
+func applyLine(config []string, line string) ([]string, bool) {
+	line = normalizeLine(line)
+	switch {
+	case line == "":
+		return config, false
+	case strings.HasPrefix(line, "set "):
+		if slices.ContainsFunc(config, func(s string) bool {
+			return s == line || strings.HasPrefix(s, line+" ")
+		}) {
+			return config, false
+		}
+		// the new line replace the lines of its parent statements
+		config = slices.DeleteFunc(config, func(s string) bool {
+			return strings.HasPrefix(line, s+" ")
+		})
+
+		return append(config, line), true
+	case strings.HasPrefix(line, "delete "):
+		statement := "set " + strings.TrimPrefix(line, "delete ")
+		deactivate := "deactivate " + strings.TrimPrefix(line, "delete ")
+		length := len(config)
+		config = slices.DeleteFunc(config, func(s string) bool {
+			return s == statement || strings.HasPrefix(s, statement+" ") ||
+				s == deactivate || strings.HasPrefix(s, deactivate+" ")
+		})
+
+		return config, len(config) != length
+	case strings.HasPrefix(line, "deactivate "):
+		if slices.Contains(config, line) {
+			return config, false
+		}
+
+		return append(config, line), true
+	case strings.HasPrefix(line, "activate "):
+		deactivate := "de" + line
+		length := len(config)
+		config = slices.DeleteFunc(config, func(s string) bool {
+			return s == deactivate
+		})
+
+		return config, len(config) != length
+	}
+
+	return config, false
+}
+
+// normalizeLine remove the unnecessary quotes around words in line like the device
+// (a quoted word without space or special character is displayed without quotes).
+func normalizeLine(line string) string {
+	return strings.Join(utils.ConfigLineWords(line), " ")
+}
+
+// showLines return the lines under the configuration path
+// (without the path if relative).
+func showLines(config []string, path string, relative bool) []string {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return slices.Clone(config)
+	}
+	var lines []string
+	for _, line := range config {
+		verb, statement, _ := strings.Cut(line, " ")
+		switch {
+		case statement == path:
+			if relative {
+				lines = append(lines, verb)
+			} else {
+				lines = append(lines, line)
+			}
+		case strings.HasPrefix(statement, path+" "):
+			if relative {
+				lines = append(lines, verb+" "+strings.TrimPrefix(statement, path+" "))
+			} else {
+				lines = append(lines, line)
+			}
+		}
+	}
+
+	return lines
+}
+
+// compareLines return the differences between two configurations
+// with the lines prefixed by '-' (only in old) or '+' (only in new).
+func compareLines(oldConfig, newConfig []string) []string {
+	var diff []string
+	for _, line := range oldConfig {
+		if !slices.Contains(newConfig, line) {
+			diff = append(diff, "- "+line)
+		}
+	}
+	for _, line := range newConfig {
+		if !slices.Contains(oldConfig, line) {
+			diff = append(diff, "+ "+line)
+		}
+	}
+
+	return diff
+}
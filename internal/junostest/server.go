@@ -0,0 +1,254 @@
+// Package junostest provides a fake Junos device reachable with NETCONF over SSH
+// to test the provider without a real device.
+package junostest
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const netconfMsgSeparator = "]]>]]>"
+
+// Server: fake Junos device with NETCONF over SSH listening on a random port of 127.0.0.1.
+//
+// The device stores the configuration as set lines in memory
+// and only understands the RPCs used by the provider to manage the configuration:
+// set/delete lines loaded with <load-configuration action="set">,
+// `show configuration <path> | display set [relative]` commands,
+// lock/unlock (shared, private and exclusive modes), commit, rollback and commit history.
+//
+// All usernames and passwords are accepted.
+type Server struct {
+	device *device
+
+	listener  net.Listener
+	sshConfig *ssh.ServerConfig
+
+	conns      map[net.Conn]struct{}
+	mutexConns sync.Mutex
+	wg         sync.WaitGroup
+}
+
+// NewServer start a fake Junos device.
+func NewServer() (*Server, error) {
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating host key: %w", err)
+	}
+	signer, err := ssh.NewSignerFromKey(hostKey)
+	if err != nil {
+		return nil, fmt.Errorf("generating host key signer: %w", err)
+	}
+	sshConfig := &ssh.ServerConfig{
+		PasswordCallback: func(_ ssh.ConnMetadata, _ []byte) (*ssh.Permissions, error) {
+			return nil, nil
+		},
+		PublicKeyCallback: func(_ ssh.ConnMetadata, _ ssh.PublicKey) (*ssh.Permissions, error) {
+			return nil, nil
+		},
+		KeyboardInteractiveCallback: func(_ ssh.ConnMetadata, _ ssh.KeyboardInteractiveChallenge) (
+			*ssh.Permissions, error,
+		) {
+			return nil, nil
+		},
+	}
+	sshConfig.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("listening on 127.0.0.1: %w", err)
+	}
+	srv := &Server{
+		device:    newDevice(),
+		listener:  listener,
+		sshConfig: sshConfig,
+		conns:     make(map[net.Conn]struct{}),
+	}
+	srv.wg.Add(1)
+	go srv.serve()
+
+	return srv, nil
+}
+
+// NewTestServer start a fake Junos device with the set lines of configuration for a test
+// and stop it at the end of test.
+func NewTestServer(tb testing.TB, config ...string) *Server {
+	tb.Helper()
+
+	srv, err := NewServer()
+	if err != nil {
+		tb.Fatalf("starting simulator: %s", err)
+	}
+	tb.Cleanup(srv.Close)
+	if len(config) > 0 {
+		srv.LoadConfig(config)
+	}
+
+	return srv
+}
+
+// IP return the IP address of device.
+func (srv *Server) IP() string {
+	host, _, _ := net.SplitHostPort(srv.listener.Addr().String())
+
+	return host
+}
+
+// Port return the port of NETCONF over SSH on device.
+func (srv *Server) Port() int {
+	_, port, _ := net.SplitHostPort(srv.listener.Addr().String())
+	v, _ := strconv.Atoi(port)
+
+	return v
+}
+
+// Close stop listening and close the opened connections.
+func (srv *Server) Close() {
+	_ = srv.listener.Close()
+	srv.mutexConns.Lock()
+	for conn := range srv.conns {
+		_ = conn.Close()
+	}
+	srv.mutexConns.Unlock()
+	srv.wg.Wait()
+}
+
+// LoadConfig replace the committed (and candidate) configuration with set lines.
+func (srv *Server) LoadConfig(lines []string) {
+	srv.device.loadConfig(lines)
+}
+
+// Config return the set lines of committed configuration.
+func (srv *Server) Config() []string {
+	return srv.device.config()
+}
+
+// Candidate return the set lines of shared candidate configuration.
+func (srv *Server) Candidate() []string {
+	return srv.device.candidateConfig()
+}
+
+// SetCommitError make the next commits (except the commit checks) fail with the error message
+// (or succeed again with an empty message).
+func (srv *Server) SetCommitError(message string) {
+	srv.device.setCommitError(message)
+}
+
+// SetCommitCheckError make the next commit checks fail with the error message
+// (or succeed again with an empty message).
+func (srv *Server) SetCommitCheckError(message string) {
+	srv.device.setCommitCheckError(message)
+}
+
+// Commits return the history of commits, from the most recent.
+func (srv *Server) Commits() []Commit {
+	return srv.device.commitHistory()
+}
+
+func (srv *Server) serve() {
+	defer srv.wg.Done()
+	for {
+		conn, err := srv.listener.Accept()
+		if err != nil {
+			return
+		}
+		srv.mutexConns.Lock()
+		srv.conns[conn] = struct{}{}
+		srv.mutexConns.Unlock()
+		srv.wg.Add(1)
+		go func() {
+			defer srv.wg.Done()
+			srv.handleConn(conn)
+			srv.mutexConns.Lock()
+			delete(srv.conns, conn)
+			srv.mutexConns.Unlock()
+		}()
+	}
+}
+
+func (srv *Server) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	sshConn, channels, requests, err := ssh.NewServerConn(conn, srv.sshConfig)
+	if err != nil {
+		return
+	}
+	defer sshConn.Close()
+	go ssh.DiscardRequests(requests)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+
+			continue
+		}
+		channel, chanRequests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			srv.handleChannel(channel, chanRequests, sshConn.User())
+		}()
+	}
+}
+
+func (srv *Server) handleChannel(channel ssh.Channel, requests <-chan *ssh.Request, user string) {
+	defer channel.Close()
+
+	for req := range requests {
+		var payload struct {
+			Name string
+		}
+		if req.Type != "subsystem" || ssh.Unmarshal(req.Payload, &payload) != nil || payload.Name != "netconf" {
+			_ = req.Reply(false, nil)
+
+			continue
+		}
+		_ = req.Reply(true, nil)
+		go ssh.DiscardRequests(requests)
+
+		srv.device.newSession(user).run(channel)
+
+		return
+	}
+}
+
+// readNetconfMessage read the next message delimited by the NETCONF 1.0 separator.
+func readNetconfMessage(reader *bufio.Reader) ([]byte, error) {
+	var msg []byte
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) && len(bytes.TrimSpace(msg)) > 0 {
+				return nil, io.ErrUnexpectedEOF
+			}
+
+			return nil, err
+		}
+		msg = append(msg, b)
+		if bytes.HasSuffix(msg, []byte(netconfMsgSeparator)) {
+			return bytes.TrimSpace(msg[:len(msg)-len(netconfMsgSeparator)]), nil
+		}
+	}
+}
+
+func writeNetconfMessage(writer io.Writer, msg string) error {
+	_, err := io.WriteString(writer, msg+netconfMsgSeparator+"\n")
+
+	return err
+}
@@ -0,0 +1,264 @@
+package junostest_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+	"github.com/jeremmfr/terraform-provider-junos/internal/junostest"
+)
+
+func newTestClient(t *testing.T, srv *junostest.Server, configMode string) *junos.Client {
+	t.Helper()
+
+	clt, err := junos.NewClient(srv.IP()).
+		WithPort(srv.Port()).
+		WithUserName("test").
+		WithPassword("test").
+		WithSleepShort(0).
+		WithSleepLock(0).
+		WithConfigMode(configMode)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return clt
+}
+
+func TestServerConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, configMode := range []string{
+		junos.ConfigModeShared,
+		junos.ConfigModePrivate,
+		junos.ConfigModeExclusive,
+	} {
+		t.Run(configMode, func(t *testing.T) {
+			t.Parallel()
+
+			srv := junostest.NewTestServer(t)
+			srv.LoadConfig([]string{
+				"set system host-name junostest",
+			})
+			ctx := context.Background()
+
+			junSess, err := newTestClient(t, srv, configMode).StartNewSession(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			defer junSess.Close()
+			if v := junSess.SystemInformation.HardwareModel; v != "vsrx" {
+				t.Errorf("unexpected hardware model: got %q", v)
+			}
+
+			if err := junSess.ConfigLock(ctx); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if err := junSess.ConfigSet(ctx, []string{
+				"set applications application app1 protocol tcp",
+				"set applications application app1 destination-port 22",
+				"set applications application app1 description \"test & app\"",
+				"set applications application app2 protocol udp",
+				"delete applications application app2",
+			}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if v := srv.Config(); len(v) != 1 {
+				t.Errorf("unexpected committed config before commit: %q", v)
+			}
+			if _, err := junSess.CommitConf(ctx, "create resource junos_application"); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if errs := junSess.ConfigUnlock(ctx); len(errs) > 0 {
+				t.Fatalf("unexpected errors: %v", errs)
+			}
+
+			expectConfig := []string{
+				"set system host-name junostest",
+				"set applications application app1 protocol tcp",
+				"set applications application app1 destination-port 22",
+				"set applications application app1 description \"test & app\"",
+			}
+			if v := srv.Config(); !slices.Equal(v, expectConfig) {
+				t.Errorf("unexpected committed config: got %q, want %q", v, expectConfig)
+			}
+			if v := srv.Commits(); len(v) != 1 || v[0].Log != "create resource junos_application" || v[0].User != "test" {
+				t.Errorf("unexpected commits: %+v", v)
+			}
+
+			showConfig, err := junSess.Command(ctx, junos.CmdShowConfig+
+				"applications application app1"+junos.PipeDisplaySetRelative)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			for _, line := range []string{
+				"set protocol tcp",
+				"set destination-port 22",
+				"set description \"test &amp; app\"",
+			} {
+				if !strings.Contains(showConfig, line+"\n") {
+					t.Errorf("line %q not found in output %q", line, showConfig)
+				}
+			}
+
+			showConfig, err = junSess.Command(ctx, junos.CmdShowConfig+
+				"applications application app2"+junos.PipeDisplaySet)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if showConfig != junos.EmptyW {
+				t.Errorf("unexpected output for a missing config: %q", showConfig)
+			}
+		})
+	}
+}
+
+func TestServerConfigLock(t *testing.T) {
+	t.Parallel()
+
+	srv := junostest.NewTestServer(t)
+	ctx := context.Background()
+
+	clt := newTestClient(t, srv, junos.ConfigModeExclusive)
+	junSess, err := clt.StartNewSession(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer junSess.Close()
+	junSess2, err := clt.StartNewSession(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer junSess2.Close()
+
+	if err := junSess.ConfigLock(ctx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := junSess.ConfigSet(ctx, []string{"set system host-name uncommitted"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctxLock, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := junSess2.ConfigLock(ctxLock); err == nil {
+		t.Errorf("expected error when locking a locked configuration")
+	}
+
+	// exclusive mode discards the uncommitted changes on unlock
+	if errs := junSess.ConfigUnlock(ctx); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if v := srv.Candidate(); len(v) != 0 {
+		t.Errorf("unexpected candidate config after unlock: %q", v)
+	}
+
+	if err := junSess2.ConfigLock(ctx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if errs := junSess2.ConfigUnlock(ctx); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestServerConfigDiscard(t *testing.T) {
+	t.Parallel()
+
+	srv := junostest.NewTestServer(t)
+	srv.LoadConfig([]string{
+		"set system host-name junostest",
+	})
+	ctx := context.Background()
+
+	junSess, err := newTestClient(t, srv, junos.ConfigModeShared).StartNewSession(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer junSess.Close()
+
+	if err := junSess.ConfigLock(ctx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer junSess.ConfigUnlock(ctx) //nolint:errcheck
+
+	if changes, err := junSess.ConfigDiscard(ctx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if changes != "" {
+		t.Errorf("unexpected changes without modification: %q", changes)
+	}
+	if err := junSess.ConfigSet(ctx, []string{"delete system host-name"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	changes, err := junSess.ConfigDiscard(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if changes != "- set system host-name junostest" {
+		t.Errorf("unexpected changes: %q", changes)
+	}
+	if v := srv.Candidate(); !slices.Equal(v, srv.Config()) {
+		t.Errorf("unexpected candidate config after discard: %q", v)
+	}
+}
+
+func TestServerCommitError(t *testing.T) {
+	t.Parallel()
+
+	srv := junostest.NewTestServer(t, "set system host-name junostest")
+	srv.SetCommitError("commit failed")
+	ctx := context.Background()
+
+	junSess, err := newTestClient(t, srv, junos.ConfigModeShared).StartNewSession(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer junSess.Close()
+
+	if err := junSess.ConfigLock(ctx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer junSess.ConfigUnlock(ctx) //nolint:errcheck
+
+	if err := junSess.ConfigSet(ctx, []string{"set system host-name router1"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := junSess.CommitConf(ctx, "test"); err == nil || !strings.Contains(err.Error(), "commit failed") {
+		t.Errorf("unexpected error on commit: %v", err)
+	}
+	if v := srv.Commits(); len(v) != 0 {
+		t.Errorf("unexpected commits: %v", v)
+	}
+
+	srv.SetCommitError("")
+	if _, err := junSess.CommitConf(ctx, "test"); err != nil {
+		t.Errorf("unexpected error on commit: %s", err)
+	}
+}
+
+func TestServerCommitCheckError(t *testing.T) {
+	t.Parallel()
+
+	srv := junostest.NewTestServer(t, "set system host-name junostest")
+	srv.SetCommitCheckError("commit check failed")
+	ctx := context.Background()
+
+	junSess, err := newTestClient(t, srv, junos.ConfigModeShared).StartNewSession(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer junSess.Close()
+
+	if _, err := junSess.ConfigCommitCheck(ctx, []string{"set system host-name router1"}); err == nil ||
+		!strings.Contains(err.Error(), "commit check failed") {
+		t.Errorf("unexpected error on commit check: %v", err)
+	}
+
+	srv.SetCommitCheckError("")
+	if _, err := junSess.ConfigCommitCheck(ctx, []string{"set system host-name router1"}); err != nil {
+		t.Errorf("unexpected error on commit check: %s", err)
+	}
+	if v := srv.Config(); !slices.Equal(v, []string{"set system host-name junostest"}) {
+		t.Errorf("unexpected config after commit check: %q", v)
+	}
+}
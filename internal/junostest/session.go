@@ -0,0 +1,526 @@
+package junostest
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	xmlnsNetconf = "urn:ietf:params:xml:ns:netconf:base:1.0"
+	xmlnsJunos   = "http://xml.juniper.net/junos/23.4R1/junos"
+
+	deviceHostName      = "junostest"
+	deviceHardwareModel = "vsrx"
+	deviceOSName        = "junos"
+	deviceOSVersion     = "23.4R1.9"
+	deviceSerialNumber  = "JUNOSTEST0001"
+)
+
+var (
+	rpcMessageIDRegexp = regexp.MustCompile(`message-id="([^"]*)"`)
+	rpcMethodRegexp    = regexp.MustCompile(`^<([\w:-]+)((?:\s[^>]*?)?)(/?)>`)
+	rpcAttrRegexp      = regexp.MustCompile(`([\w:-]+)="([^"]*)"`)
+	showConfigRegexp   = regexp.MustCompile(
+		`^show configuration(?: (.*?))?\s*\|\s*display set( relative)?$`,
+	)
+)
+
+// session: a NETCONF session opened on device.
+type session struct {
+	id     int
+	user   string
+	device *device
+
+	// private candidate configuration opened with open-configuration
+	private []string
+	// private candidate configuration is opened
+	privateOpened bool
+}
+
+// rpcMethod: the method of a rpc received from client.
+type rpcMethod struct {
+	name  string
+	attrs map[string]string
+	body  string
+}
+
+// rpcError: an error or a warning to send in reply of a rpc.
+type rpcError struct {
+	severity string
+	message  string
+}
+
+func (d *device) newSession(user string) *session {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.lastSessionID++
+
+	return &session{
+		id:     d.lastSessionID,
+		user:   user,
+		device: d,
+	}
+}
+
+func (sess *session) run(rw io.ReadWriter) {
+	defer sess.close()
+
+	if err := writeNetconfMessage(rw, "<hello xmlns=\""+xmlnsNetconf+"\">"+
+		"<capabilities>"+
+		"<capability>urn:ietf:params:netconf:base:1.0</capability>"+
+		"<capability>urn:ietf:params:netconf:capability:candidate:1.0</capability>"+
+		"<capability>http://xml.juniper.net/netconf/junos/1.0</capability>"+
+		"</capabilities>"+
+		"<session-id>"+strconv.Itoa(sess.id)+"</session-id>"+
+		"</hello>",
+	); err != nil {
+		return
+	}
+
+	reader := bufio.NewReader(rw)
+	for {
+		msg, err := readNetconfMessage(reader)
+		if err != nil {
+			return
+		}
+		if len(msg) == 0 || !strings.Contains(string(msg), "<rpc") {
+			// hello of client
+			continue
+		}
+		messageID := ""
+		if v := rpcMessageIDRegexp.FindStringSubmatch(string(msg)); v != nil {
+			messageID = v[1]
+		}
+		method, err := parseRPCMethod(string(msg))
+		var (
+			data         string
+			errs         []rpcError
+			closeSession bool
+		)
+		if err != nil {
+			errs = []rpcError{{severity: "error", message: err.Error()}}
+		} else {
+			data, errs, closeSession = sess.handle(method)
+		}
+		if err := writeNetconfMessage(rw, rpcReply(messageID, data, errs)); err != nil {
+			return
+		}
+		if closeSession {
+			return
+		}
+	}
+}
+
+// close release the lock of configuration and the private candidate configuration
+// when the session ends.
+func (sess *session) close() {
+	sess.device.mutex.Lock()
+	defer sess.device.mutex.Unlock()
+
+	sess.releaseLock()
+	sess.private = nil
+	sess.privateOpened = false
+}
+
+// releaseLock release the lock of configuration if owned by session
+// (with discarding the uncommitted changes if exclusive lock).
+//
+// sess.device.mutex need to be locked.
+func (sess *session) releaseLock() bool {
+	d := sess.device
+	if d.lockOwner != sess.id || d.lockMode == lockNone {
+		return false
+	}
+	if d.lockMode == lockExclusive {
+		d.candidate = slices.Clone(d.revisions[0].lines)
+	}
+	d.lockOwner = 0
+	d.lockMode = lockNone
+
+	return true
+}
+
+// target return the candidate configuration used by the session.
+//
+// sess.device.mutex need to be locked.
+func (sess *session) target() *[]string {
+	if sess.privateOpened {
+		return &sess.private
+	}
+
+	return &sess.device.candidate
+}
+
+func (sess *session) handle(method rpcMethod) (data string, errs []rpcError, closeSession bool) {
+	sess.device.mutex.Lock()
+	defer sess.device.mutex.Unlock()
+
+	switch method.name {
+	case "close-session":
+		return "<ok/>", nil, true
+	case "get-system-information":
+		return sess.getSystemInformation(), nil, false
+	case "get-route-engine-information":
+		return "<route-engine-information xmlns=\"" + xmlnsJunos + "\">" +
+			"<route-engine>" +
+			"<slot>0</slot>" +
+			"<mastership-state>master</mastership-state>" +
+			"</route-engine>" +
+			"</route-engine-information>", nil, false
+	case "lock":
+		if !hasElement(method.body, "candidate") {
+			return "", errorf("lock of target not supported by simulator"), false
+		}
+
+		return sess.lock(lockShared)
+	case "lock-configuration":
+		return sess.lock(lockExclusive)
+	case "unlock":
+		if !hasElement(method.body, "candidate") {
+			return "", errorf("unlock of target not supported by simulator"), false
+		}
+		if !sess.releaseLock() {
+			return "", errorf("configuration database not locked by this session"), false
+		}
+
+		return "<ok/>", nil, false
+	case "unlock-configuration":
+		if !sess.releaseLock() {
+			return "", errorf("configuration database not locked by this session"), false
+		}
+
+		return "<ok/>", nil, false
+	case "open-configuration":
+		if !hasElement(method.body, "private") {
+			return "", errorf("open-configuration without private not supported by simulator"), false
+		}
+		if sess.device.lockMode == lockExclusive {
+			return "", errorf("configuration database locked by session %d", sess.device.lockOwner), false
+		}
+		sess.privateOpened = true
+		sess.private = slices.Clone(sess.device.revisions[0].lines)
+
+		return "<ok/>", nil, false
+	case "close-configuration":
+		sess.privateOpened = false
+		sess.private = nil
+
+		return "<ok/>", nil, false
+	case "load-configuration":
+		return sess.loadConfiguration(method)
+	case "commit-configuration":
+		return sess.commitConfiguration(method)
+	case "get-configuration":
+		return sess.getConfiguration(method)
+	case "command":
+		return sess.command(html.UnescapeString(strings.TrimSpace(method.body)))
+	case "get-commit-information":
+		return sess.getCommitInformation(), nil, false
+	case "get-rollback-information":
+		return sess.getRollbackInformation(method)
+	}
+
+	return "", errorf("rpc %q not supported by simulator", method.name), false
+}
+
+func (sess *session) getSystemInformation() string {
+	return "<system-information>" +
+		"<hardware-model>" + deviceHardwareModel + "</hardware-model>" +
+		"<os-name>" + deviceOSName + "</os-name>" +
+		"<os-version>" + deviceOSVersion + "</os-version>" +
+		"<serial-number>" + deviceSerialNumber + "</serial-number>" +
+		"<host-name>" + deviceHostName + "</host-name>" +
+		"</system-information>"
+}
+
+func (sess *session) lock(mode string) (string, []rpcError, bool) {
+	d := sess.device
+	if d.lockMode != lockNone {
+		return "", errorf("configuration database locked by session %d", d.lockOwner), false
+	}
+	if mode == lockExclusive && !slices.Equal(d.candidate, d.revisions[0].lines) {
+		return "", errorf("configuration database modified"), false
+	}
+	d.lockOwner = sess.id
+	d.lockMode = mode
+
+	return "<ok/>", nil, false
+}
+
+// lockedByOther return an error if the configuration database is locked by another session.
+//
+// sess.device.mutex need to be locked.
+func (sess *session) lockedByOther() []rpcError {
+	if sess.privateOpened {
+		return nil
+	}
+	if d := sess.device; d.lockMode != lockNone && d.lockOwner != sess.id {
+		return errorf("configuration database locked by session %d", d.lockOwner)
+	}
+
+	return nil
+}
+
+func (sess *session) loadConfiguration(method rpcMethod) (string, []rpcError, bool) {
+	if errs := sess.lockedByOther(); errs != nil {
+		return "", errs, false
+	}
+	target := sess.target()
+	if v, ok := method.attrs["rollback"]; ok {
+		rollback, err := strconv.Atoi(v)
+		if err != nil || rollback < 0 || rollback >= len(sess.device.revisions) {
+			return "", errorf("rollback %s not found", v), false
+		}
+		*target = slices.Clone(sess.device.revisions[rollback].lines)
+
+		return "<load-configuration-results><ok/></load-configuration-results>", nil, false
+	}
+	if method.attrs["action"] != "set" || method.attrs["format"] != "text" {
+		return "", errorf("load-configuration with action %q and format %q not supported by simulator",
+			method.attrs["action"], method.attrs["format"]), false
+	}
+
+	var errs []rpcError
+	for line := range strings.SplitSeq(html.UnescapeString(elementText(method.body, "configuration-set")), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		verb, _, _ := strings.Cut(line, " ")
+		switch verb {
+		case "set", "delete", "activate", "deactivate":
+		default:
+			errs = append(errs, rpcError{severity: "error", message: "syntax error: " + line})
+
+			continue
+		}
+		var modified bool
+		*target, modified = applyLine(*target, line)
+		if !modified && verb == "delete" {
+			errs = append(errs, rpcError{severity: "warning", message: "statement not found: " + line})
+		}
+	}
+
+	return "<load-configuration-results><ok/></load-configuration-results>", errs, false
+}
+
+func (sess *session) commitConfiguration(method rpcMethod) (string, []rpcError, bool) {
+	if hasElement(method.body, "at-time") {
+		return "", errorf("commit at-time not supported by simulator"), false
+	}
+	if errs := sess.lockedByOther(); errs != nil {
+		return "", errs, false
+	}
+	if hasElement(method.body, "check") {
+		if v := sess.device.commitCheckError; v != "" {
+			return "", errorf("%s", v), false
+		}
+
+		return commitResults("commit-check-success"), nil, false
+	}
+
+	d := sess.device
+	if d.commitError != "" {
+		return "", errorf("%s", d.commitError), false
+	}
+	lines := slices.Clone(*sess.target())
+	if sess.privateOpened {
+		if !slices.Equal(d.candidate, d.revisions[0].lines) {
+			return "", errorf("shared configuration database modified"), false
+		}
+		d.candidate = slices.Clone(lines)
+	}
+	d.commit(lines, Commit{
+		User:   sess.user,
+		Client: "netconf",
+		Log:    html.UnescapeString(elementText(method.body, "log")),
+		Time:   time.Now().UTC().Truncate(time.Second),
+	})
+
+	return commitResults("commit-success"), nil, false
+}
+
+func (sess *session) getConfiguration(method rpcMethod) (string, []rpcError, bool) {
+	if method.attrs["compare"] == "rollback" {
+		if method.attrs["rollback"] != "0" {
+			return "", errorf("compare with rollback %q not supported by simulator", method.attrs["rollback"]), false
+		}
+		diff := compareLines(sess.device.revisions[0].lines, *sess.target())
+		if len(diff) == 0 {
+			return "", nil, false
+		}
+
+		return "<configuration-information><configuration-output>\n" +
+			escapeText(strings.Join(diff, "\n")) + "\n" +
+			"</configuration-output></configuration-information>", nil, false
+	}
+	if method.attrs["format"] != "set" {
+		return "", errorf("get-configuration with format %q not supported by simulator", method.attrs["format"]), false
+	}
+	lines := *sess.target()
+	if method.attrs["database"] == "committed" {
+		lines = sess.device.revisions[0].lines
+	}
+
+	return "<configuration-set>\n" + escapeText(strings.Join(lines, "\n")) + "\n</configuration-set>", nil, false
+}
+
+func (sess *session) command(cmd string) (string, []rpcError, bool) {
+	v := showConfigRegexp.FindStringSubmatch(strings.TrimSpace(cmd))
+	if v == nil {
+		return "", errorf("command %q not supported by simulator", cmd), false
+	}
+	lines := showLines(sess.device.revisions[0].lines, normalizeLine(v[1]), v[2] != "")
+	if len(lines) == 0 {
+		return "\n", nil, false
+	}
+
+	return "<configuration-output>\n" + escapeText(strings.Join(lines, "\n")) + "\n</configuration-output>", nil, false
+}
+
+func (sess *session) getCommitInformation() string {
+	var data strings.Builder
+	data.WriteString("<commit-information>")
+	for i, rev := range sess.device.revisions {
+		if rev.commit.Time.IsZero() {
+			continue
+		}
+		data.WriteString("<commit-history>" +
+			"<sequence-number>" + strconv.Itoa(i) + "</sequence-number>" +
+			"<user>" + escapeText(rev.commit.User) + "</user>" +
+			"<client>" + escapeText(rev.commit.Client) + "</client>" +
+			"<date-time junos:seconds=\"" + strconv.FormatInt(rev.commit.Time.Unix(), 10) + "\">" +
+			rev.commit.Time.Format("2006-01-02 15:04:05 MST") +
+			"</date-time>")
+		if rev.commit.Log != "" {
+			data.WriteString("<log>" + escapeText(rev.commit.Log) + "</log>")
+		}
+		data.WriteString("</commit-history>")
+	}
+	data.WriteString("</commit-information>")
+
+	return data.String()
+}
+
+func (sess *session) getRollbackInformation(method rpcMethod) (string, []rpcError, bool) {
+	rollback, err := strconv.Atoi(elementText(method.body, "rollback"))
+	if err != nil || rollback < 0 || rollback >= len(sess.device.revisions) {
+		return "", errorf("rollback %q not found", elementText(method.body, "rollback")), false
+	}
+	compare, err := strconv.Atoi(elementText(method.body, "compare"))
+	if err != nil || compare < 0 || compare >= len(sess.device.revisions) {
+		return "", errorf("rollback %q not found", elementText(method.body, "compare")), false
+	}
+	diff := compareLines(sess.device.revisions[compare].lines, sess.device.revisions[rollback].lines)
+
+	return "<rollback-information><configuration-information><configuration-output>\n" +
+		escapeText(strings.Join(diff, "\n")) + "\n" +
+		"</configuration-output></configuration-information></rollback-information>", nil, false
+}
+
+func commitResults(result string) string {
+	return "<commit-results>" +
+		"<routing-engine>" +
+		"<name>re0</name>" +
+		"<" + result + "/>" +
+		"</routing-engine>" +
+		"</commit-results>"
+}
+
+func rpcReply(messageID, data string, errs []rpcError) string {
+	var reply strings.Builder
+	reply.WriteString("<rpc-reply xmlns=\"" + xmlnsNetconf + "\" xmlns:junos=\"" + xmlnsJunos + "\"" +
+		" message-id=\"" + messageID + "\">")
+	for _, e := range errs {
+		reply.WriteString("<rpc-error>" +
+			"<error-type>protocol</error-type>" +
+			"<error-tag>operation-failed</error-tag>" +
+			"<error-severity>" + e.severity + "</error-severity>" +
+			"<error-message>" + escapeText(e.message) + "</error-message>" +
+			"</rpc-error>")
+	}
+	if data == "" && len(errs) == 0 {
+		data = "<ok/>"
+	}
+	reply.WriteString(data)
+	reply.WriteString("</rpc-reply>")
+
+	return reply.String()
+}
+
+func errorf(format string, a ...any) []rpcError {
+	return []rpcError{{severity: "error", message: fmt.Sprintf(format, a...)}}
+}
+
+// parseRPCMethod extract the method of rpc without decoding xml
+// as the set lines in configuration are not escaped by client.
+func parseRPCMethod(msg string) (rpcMethod, error) {
+	start := strings.Index(msg, "<rpc")
+	if start == -1 {
+		return rpcMethod{}, fmt.Errorf("rpc not found in message %q", msg)
+	}
+	msg = msg[start:]
+	endStartTag := strings.Index(msg, ">")
+	endTag := strings.LastIndex(msg, "</rpc>")
+	if endStartTag == -1 || endTag == -1 || endTag < endStartTag {
+		return rpcMethod{}, fmt.Errorf("malformed rpc in message %q", msg)
+	}
+	inner := strings.TrimSpace(msg[endStartTag+1 : endTag])
+
+	v := rpcMethodRegexp.FindStringSubmatch(inner)
+	if v == nil {
+		return rpcMethod{}, fmt.Errorf("method not found in rpc %q", inner)
+	}
+	method := rpcMethod{
+		name:  v[1],
+		attrs: make(map[string]string),
+	}
+	for _, attr := range rpcAttrRegexp.FindAllStringSubmatch(v[2], -1) {
+		method.attrs[attr[1]] = html.UnescapeString(attr[2])
+	}
+	if v[3] != "/" {
+		body := inner[len(v[0]):]
+		if end := strings.LastIndex(body, "</"+method.name+">"); end != -1 {
+			body = body[:end]
+		}
+		method.body = body
+	}
+
+	return method, nil
+}
+
+// hasElement return if the element with name is in body.
+func hasElement(body, name string) bool {
+	return strings.Contains(body, "<"+name+"/>") ||
+		strings.Contains(body, "<"+name+">") ||
+		strings.Contains(body, "<"+name+" ")
+}
+
+// elementText return the raw content of the first element with name in body.
+func elementText(body, name string) string {
+	start := strings.Index(body, "<"+name+">")
+	if start == -1 {
+		return ""
+	}
+	body = body[start+len(name)+2:]
+	end := strings.LastIndex(body, "</"+name+">")
+	if end == -1 {
+		return ""
+	}
+
+	return strings.TrimSpace(body[:end])
+}
+
+// escapeText escape the characters in text like the device
+// (without escaping quotes which are used in set lines).
+func escapeText(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+	"github.com/jeremmfr/terraform-provider-junos/internal/tfdiag"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action                   = &commitAtAction{}
+	_ action.ActionWithConfigure      = &commitAtAction{}
+	_ action.ActionWithValidateConfig = &commitAtAction{}
+)
+
+type commitAtAction struct {
+	client *junos.Client
+}
+
+func newCommitAtAction() action.Action {
+	return &commitAtAction{}
+}
+
+func (act *commitAtAction) typeName() string {
+	return providerName + "_commit_at"
+}
+
+func (act *commitAtAction) junosClient() *junos.Client {
+	return act.client
+}
+
+func (act *commitAtAction) Metadata(
+	_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_commit_at"
+}
+
+func (act *commitAtAction) Configure(
+	ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*junos.Client)
+	if !ok {
+		unexpectedActionConfigureType(ctx, req, resp)
+
+		return
+	}
+	act.client = client
+}
+
+func (act *commitAtAction) Schema(
+	_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Load an arbitrary configuration and schedule its commit at a specific time.",
+		Attributes: map[string]schema.Attribute{
+			"config": schema.StringAttribute{
+				Required:    true,
+				Description: "The configuration to load and commit at `at_time`.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"at_time": schema.StringAttribute{
+				Required: true,
+				Description: "Time to activate the configuration" +
+					" (`hh:mm[:ss]`, `yyyy-mm-dd hh:mm[:ss]` or `reboot`).",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(
+						`^(reboot|(\d{4}-\d{2}-\d{2} )?\d{1,2}:\d{2}(:\d{2})?)$`),
+						"must be in the format `hh:mm[:ss]`, `yyyy-mm-dd hh:mm[:ss]` or `reboot`"),
+				},
+			},
+			"action": schema.StringAttribute{
+				Optional:    true,
+				Description: "Specify how to load the configuration data. Defaults to 'merge'.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						junos.LoadConfigActionMerge,
+						junos.LoadConfigActionOverride,
+						junos.LoadConfigActionReplace,
+						junos.LoadConfigActionSet,
+						junos.LoadConfigActionUpdate,
+					),
+				},
+			},
+			"format": schema.StringAttribute{
+				Optional:    true,
+				Description: "The format used for the configuration data. Defaults to 'text'.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						junos.ConfigFormatText,
+						junos.ConfigFormatJSON,
+						junos.ConfigFormatXML,
+					),
+				},
+			},
+		},
+	}
+}
+
+type commitAtActionData struct {
+	Config types.String `tfsdk:"config"`
+	AtTime types.String `tfsdk:"at_time"`
+	Action types.String `tfsdk:"action"`
+	Format types.String `tfsdk:"format"`
+}
+
+func (act *commitAtAction) ValidateConfig(
+	ctx context.Context, req action.ValidateConfigRequest, resp *action.ValidateConfigResponse,
+) {
+	var config commitAtActionData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Format.IsNull() && !config.Format.IsUnknown() {
+		format := config.Format.ValueString()
+		actionValue := config.Action.ValueString()
+		if actionValue == "" {
+			actionValue = junos.LoadConfigActionMerge
+		}
+		if actionValue == junos.LoadConfigActionSet && format != junos.ConfigFormatText {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("format"),
+				tfdiag.ConflictConfigErrSummary,
+				fmt.Sprintf("format cannot be %q when action = %q, must be 'text'", format, actionValue),
+			)
+		}
+	}
+}
+
+func (act *commitAtAction) Invoke(
+	ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse,
+) {
+	var config commitAtActionData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	format := config.Format.ValueString()
+	if format == "" {
+		format = junos.ConfigFormatText
+	}
+	actionValue := config.Action.ValueString()
+	if actionValue == "" {
+		actionValue = junos.LoadConfigActionMerge
+	}
+
+	if format != junos.ConfigFormatText && actionValue == junos.LoadConfigActionSet {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("format"),
+			tfdiag.ConflictConfigErrSummary,
+			fmt.Sprintf("format cannot be %q when action = %q, must be %q", format, actionValue, junos.ConfigFormatText),
+		)
+
+		return
+	}
+
+	clt := act.junosClient()
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Starting session to device",
+	})
+	junSess, err := clt.StartNewSession(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(tfdiag.StartSessErrSummary, err.Error())
+
+		return
+	}
+	defer junSess.Close()
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Locking candidate configuration",
+	})
+	if err := junSess.ConfigLock(ctx); err != nil {
+		resp.Diagnostics.AddError(tfdiag.ConfigLockErrSummary, err.Error())
+
+		return
+	}
+	defer func() {
+		discardChangesOnError(ctx, junSess, &resp.Diagnostics)
+		resp.Diagnostics.Append(tfdiag.Warns(tfdiag.ConfigUnlockWarnSummary, junSess.ConfigUnlock(ctx))...)
+	}()
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Loading configuration",
+	})
+	if err := junSess.ConfigLoad(ctx, actionValue, format, config.Config.ValueString()); err != nil {
+		resp.Diagnostics.AddError(tfdiag.ConfigSetErrSummary, err.Error())
+
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Scheduling commit at " + config.AtTime.ValueString(),
+	})
+	warns, err := junSess.CommitConfAt(ctx, config.AtTime.ValueString(),
+		"schedule a commit of config with action "+act.typeName())
+	resp.Diagnostics.Append(tfdiag.Warns(tfdiag.ConfigCommitWarnSummary, warns)...)
+	if err != nil {
+		resp.Diagnostics.AddError(tfdiag.ConfigCommitErrSummary, err.Error())
+
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Configuration loaded and commit scheduled",
+	})
+}
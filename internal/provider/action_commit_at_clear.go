@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+	"github.com/jeremmfr/terraform-provider-junos/internal/tfdiag"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action              = &commitAtClearAction{}
+	_ action.ActionWithConfigure = &commitAtClearAction{}
+)
+
+type commitAtClearAction struct {
+	client *junos.Client
+}
+
+func newCommitAtClearAction() action.Action {
+	return &commitAtClearAction{}
+}
+
+func (act *commitAtClearAction) junosClient() *junos.Client {
+	return act.client
+}
+
+func (act *commitAtClearAction) Metadata(
+	_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_commit_at_clear"
+}
+
+func (act *commitAtClearAction) Configure(
+	ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*junos.Client)
+	if !ok {
+		unexpectedActionConfigureType(ctx, req, resp)
+
+		return
+	}
+	act.client = client
+}
+
+func (act *commitAtClearAction) Schema(
+	_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Clear the pending commit scheduled at a specific time (`clear system commit`).",
+	}
+}
+
+func (act *commitAtClearAction) Invoke(
+	ctx context.Context, _ action.InvokeRequest, resp *action.InvokeResponse,
+) {
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Starting session to device",
+	})
+	junSess, err := act.junosClient().StartNewSession(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(tfdiag.StartSessErrSummary, err.Error())
+
+		return
+	}
+	defer junSess.Close()
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Clearing pending commit",
+	})
+	if _, err := junSess.CommandXML(ctx, junos.RPCClearSystemCommit); err != nil {
+		resp.Diagnostics.AddError(tfdiag.ConfigCommitErrSummary, err.Error())
+
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Pending commit cleared",
+	})
+}
@@ -0,0 +1,53 @@
+package provider_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccActionCommitAt_basic(t *testing.T) {
+	if os.Getenv("TESTACC_SRX") != "" {
+		resource.Test(t, resource.TestCase{
+			PreCheck: func() { testAccPreCheck(t) },
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(tfversion.Version1_14_0),
+			},
+			Steps: []resource.TestStep{
+				{
+					// 1
+					ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+					ConfigDirectory:          config.TestStepDirectory(),
+				},
+				{
+					// 2
+					ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+					ConfigDirectory:          config.TestStepDirectory(),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("data.junos_commit_at_pending.testacc",
+							"commits.#", "1"),
+						resource.TestCheckResourceAttr("data.junos_commit_at_pending.testacc",
+							"commits.0.at_time", "reboot"),
+					),
+				},
+				{
+					// 3
+					ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+					ConfigDirectory:          config.TestStepDirectory(),
+				},
+				{
+					// 4
+					ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+					ConfigDirectory:          config.TestStepDirectory(),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("data.junos_commit_at_pending.testacc",
+							"commits.#", "0"),
+					),
+				},
+			},
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+	"github.com/jeremmfr/terraform-provider-junos/internal/tfdiag"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action              = &commitConfirmAction{}
+	_ action.ActionWithConfigure = &commitConfirmAction{}
+)
+
+type commitConfirmAction struct {
+	client *junos.Client
+}
+
+func newCommitConfirmAction() action.Action {
+	return &commitConfirmAction{}
+}
+
+func (act *commitConfirmAction) junosClient() *junos.Client {
+	return act.client
+}
+
+func (act *commitConfirmAction) Metadata(
+	_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_commit_confirm"
+}
+
+func (act *commitConfirmAction) Configure(
+	ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*junos.Client)
+	if !ok {
+		unexpectedActionConfigureType(ctx, req, resp)
+
+		return
+	}
+	act.client = client
+}
+
+func (act *commitConfirmAction) Schema(
+	_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Confirm the commits with `confirmed` option on device to avoid the automatic rollback.",
+	}
+}
+
+func (act *commitConfirmAction) Invoke(
+	ctx context.Context, _ action.InvokeRequest, resp *action.InvokeResponse,
+) {
+	clt := act.junosClient()
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Confirming commit",
+	})
+	warns, err := clt.ConfirmCommit(ctx)
+	resp.Diagnostics.Append(tfdiag.Warns(tfdiag.ConfigCommitWarnSummary, warns)...)
+	if err != nil {
+		resp.Diagnostics.AddError(tfdiag.ConfigCommitErrSummary, err.Error())
+
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Commit confirmed",
+	})
+}
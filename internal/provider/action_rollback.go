@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+	"github.com/jeremmfr/terraform-provider-junos/internal/tfdiag"
+	"github.com/jeremmfr/terraform-provider-junos/internal/tfvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action              = &rollbackAction{}
+	_ action.ActionWithConfigure = &rollbackAction{}
+)
+
+type rollbackAction struct {
+	client *junos.Client
+}
+
+func newRollbackAction() action.Action {
+	return &rollbackAction{}
+}
+
+func (act *rollbackAction) typeName() string {
+	return providerName + "_rollback"
+}
+
+func (act *rollbackAction) junosClient() *junos.Client {
+	return act.client
+}
+
+func (act *rollbackAction) Metadata(
+	_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_rollback"
+}
+
+func (act *rollbackAction) Configure(
+	ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*junos.Client)
+	if !ok {
+		unexpectedActionConfigureType(ctx, req, resp)
+
+		return
+	}
+	act.client = client
+}
+
+func (act *rollbackAction) Schema(
+	_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Load the configuration of a previous commit (rollback) and commit it.",
+		Attributes: map[string]schema.Attribute{
+			"rollback": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Index of rollback configuration to load.",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 49),
+					int64validator.ExactlyOneOf(path.MatchRoot("rollback_before_log")),
+				},
+			},
+			"rollback_before_log": schema.StringAttribute{
+				Optional: true,
+				Description: "Regular expression to find the last commit with a matching log message" +
+					" and load the configuration before this commit.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					tfvalidator.StringRegex(),
+				},
+			},
+		},
+	}
+}
+
+type rollbackActionData struct {
+	Rollback          types.Int64  `tfsdk:"rollback"`
+	RollbackBeforeLog types.String `tfsdk:"rollback_before_log"`
+}
+
+func (act *rollbackAction) Invoke(
+	ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse,
+) {
+	var config rollbackActionData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clt := act.junosClient()
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Starting session to device",
+	})
+	junSess, err := clt.StartNewSession(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(tfdiag.StartSessErrSummary, err.Error())
+
+		return
+	}
+	defer junSess.Close()
+
+	// lock before reading the commit history so that no commit shifts the indexes of rollback
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Locking candidate configuration",
+	})
+	if err := junSess.ConfigLock(ctx); err != nil {
+		resp.Diagnostics.AddError(tfdiag.ConfigLockErrSummary, err.Error())
+
+		return
+	}
+	defer func() {
+		discardChangesOnError(ctx, junSess, &resp.Diagnostics)
+		resp.Diagnostics.Append(tfdiag.Warns(tfdiag.ConfigUnlockWarnSummary, junSess.ConfigUnlock(ctx))...)
+	}()
+
+	rollback := int(config.Rollback.ValueInt64())
+	if !config.RollbackBeforeLog.IsNull() {
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: "Reading commit history",
+		})
+		rollback, err = rollbackBeforeLog(ctx, config.RollbackBeforeLog.ValueString(), junSess)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rollback_before_log"),
+				tfdiag.ReadErrSummary,
+				err.Error(),
+			)
+
+			return
+		}
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Loading rollback " + strconv.Itoa(rollback),
+	})
+	if err := junSess.ConfigLoadRollback(ctx, rollback); err != nil {
+		resp.Diagnostics.AddError(tfdiag.ConfigSetErrSummary, err.Error())
+
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Committing configuration",
+	})
+	warns, err := junSess.CommitConf(ctx, fmt.Sprintf("rollback %d with action %s", rollback, act.typeName()))
+	resp.Diagnostics.Append(tfdiag.Warns(tfdiag.ConfigCommitWarnSummary, warns)...)
+	if err != nil {
+		resp.Diagnostics.AddError(tfdiag.ConfigCommitErrSummary, err.Error())
+
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: "Rollback " + strconv.Itoa(rollback) + " loaded and committed",
+	})
+}
+
+// rollbackBeforeLog return the index of rollback configuration before the last commit
+// with a log message matching the regular expression.
+func rollbackBeforeLog(
+	ctx context.Context, logRegex string, junSess *junos.Session,
+) (
+	int, error,
+) {
+	logRegexp, err := regexp.Compile(logRegex)
+	if err != nil {
+		return 0, fmt.Errorf("compiling regular expression %q: %w", logRegex, err)
+	}
+	commitInformations, err := readCommitInformation(ctx, junSess)
+	if err != nil {
+		return 0, err
+	}
+	if len(commitInformations) == 0 {
+		return 0, errors.New("no commit history found")
+	}
+
+	// the configuration is synchronized between routing engines, use the first one
+	for _, commitInfo := range commitInformations[0].reply.CommitHistory {
+		if !logRegexp.MatchString(commitInfo.Log) {
+			continue
+		}
+		if commitInfo.SequenceNumber+1 > 49 {
+			return 0, fmt.Errorf("no rollback configuration available before the commit %d with log %q",
+				commitInfo.SequenceNumber, commitInfo.Log)
+		}
+
+		return commitInfo.SequenceNumber + 1, nil
+	}
+
+	return 0, fmt.Errorf("no commit found with log matching %q", logRegex)
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/tfdiag"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRollbackActionWithSimulator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	srv, client := newSimulatorClient(t, "set system host-name router1")
+	junSess, err := client.StartNewSession(ctx)
+	if err != nil {
+		t.Fatalf("starting session: %s", err)
+	}
+	if err := junSess.ConfigLock(ctx); err != nil {
+		t.Fatalf("got unexpected error on lock: %s", err)
+	}
+	if err := junSess.ConfigSet(ctx, []string{"set vlans vlan10 vlan-id 10"}); err != nil {
+		t.Fatalf("got unexpected error on set: %s", err)
+	}
+	if _, err := junSess.CommitConf(ctx, "create resource junos_vlan"); err != nil {
+		t.Fatalf("got unexpected error on commit: %s", err)
+	}
+	junSess.ConfigUnlock(ctx)
+	junSess.Close()
+
+	act := actionsWithDevice([]func() action.Action{newRollbackAction})[0]()
+	act.(action.ActionWithConfigure).Configure(ctx,
+		action.ConfigureRequest{ProviderData: client}, &action.ConfigureResponse{})
+	type rollbackActionWithDeviceData struct {
+		rollbackActionData
+
+		Device types.String `tfsdk:"device"`
+	}
+
+	// the commit fails, the loaded rollback is discarded
+	srv.SetCommitError("commit failed")
+	invokeResp := invokeAction(ctx, t, act, &rollbackActionWithDeviceData{
+		rollbackActionData: rollbackActionData{
+			RollbackBeforeLog: types.StringValue("^create resource junos_vlan$"),
+		},
+	})
+	if !invokeResp.Diagnostics.HasError() ||
+		invokeResp.Diagnostics.Errors()[0].Summary() != tfdiag.ConfigCommitErrSummary {
+		t.Fatalf("got unexpected diagnostics on rollback: %v", invokeResp.Diagnostics)
+	}
+	expectConfig := []string{"set system host-name router1", "set vlans vlan10 vlan-id 10"}
+	if v := srv.Candidate(); !sameLines(v, expectConfig) {
+		t.Errorf("got unexpected candidate after error: %q, want %q", v, expectConfig)
+	}
+
+	srv.SetCommitError("")
+	invokeResp = invokeAction(ctx, t, act, &rollbackActionWithDeviceData{
+		rollbackActionData: rollbackActionData{
+			RollbackBeforeLog: types.StringValue("^create resource junos_vlan$"),
+		},
+	})
+	if invokeResp.Diagnostics.HasError() {
+		t.Fatalf("got unexpected error on rollback: %v", invokeResp.Diagnostics)
+	}
+	expectConfig = []string{"set system host-name router1"}
+	if v := srv.Config(); !sameLines(v, expectConfig) {
+		t.Errorf("got unexpected config after rollback: %q, want %q", v, expectConfig)
+	}
+}
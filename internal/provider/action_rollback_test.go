@@ -0,0 +1,48 @@
+package provider_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccActionRollback_basic(t *testing.T) {
+	if os.Getenv("TESTACC_SRX") != "" {
+		resource.Test(t, resource.TestCase{
+			PreCheck: func() { testAccPreCheck(t) },
+			TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+				tfversion.SkipBelow(tfversion.Version1_14_0),
+			},
+			Steps: []resource.TestStep{
+				{
+					// 1
+					ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+					ConfigDirectory:          config.TestStepDirectory(),
+				},
+				{
+					// 2
+					ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+					ConfigDirectory:          config.TestStepDirectory(),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("data.junos_commit_history.testacc",
+							"commits.0.log", "create resource junos_application"),
+						resource.TestCheckResourceAttrSet("data.junos_commit_history.testacc",
+							"diff"),
+						resource.TestCheckResourceAttr("data.junos_commit_history.testacc_filter",
+							"commits.#", "1"),
+						resource.TestCheckResourceAttrSet("data.junos_commit_history.testacc_filter",
+							"commits.0.timestamp"),
+					),
+				},
+				{
+					// 3
+					ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+					ConfigDirectory:          config.TestStepDirectory(),
+				},
+			},
+		})
+	}
+}
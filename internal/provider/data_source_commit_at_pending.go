@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &commitAtPendingDataSource{}
+	_ datasource.DataSourceWithConfigure = &commitAtPendingDataSource{}
+)
+
+type commitAtPendingDataSource struct {
+	client *junos.Client
+}
+
+func (dsc *commitAtPendingDataSource) typeName() string {
+	return providerName + "_commit_at_pending"
+}
+
+func (dsc *commitAtPendingDataSource) junosName() string {
+	return "system commit"
+}
+
+func (dsc *commitAtPendingDataSource) junosClient() *junos.Client {
+	return dsc.client
+}
+
+func newCommitAtPendingDataSource() datasource.DataSource {
+	return &commitAtPendingDataSource{}
+}
+
+func (dsc *commitAtPendingDataSource) Metadata(
+	_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = dsc.typeName()
+}
+
+func (dsc *commitAtPendingDataSource) Configure(
+	ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*junos.Client)
+	if !ok {
+		unexpectedDataSourceConfigureType(ctx, req, resp)
+
+		return
+	}
+	dsc.client = client
+}
+
+func (dsc *commitAtPendingDataSource) Schema(
+	_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Get the pending commits scheduled at a specific time (" + dsc.junosName() + ")",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "An identifier for the data source with value `commit_at_pending`.",
+			},
+			"commits": schema.ListAttribute{
+				Computed:    true,
+				Description: "Pending commits scheduled at a specific time.",
+				ElementType: types.ObjectType{}.WithAttributeTypes(map[string]attr.Type{
+					"re_name": types.StringType,
+					"user":    types.StringType,
+					"client":  types.StringType,
+					"at_time": types.StringType,
+				}),
+			},
+		},
+	}
+}
+
+type commitAtPendingDataSourceData struct {
+	ID      types.String                           `tfsdk:"id"`
+	Commits []commitAtPendingDataSourceBlockCommit `tfsdk:"commits"`
+}
+
+type commitAtPendingDataSourceBlockCommit struct {
+	ReName types.String `tfsdk:"re_name"`
+	User   types.String `tfsdk:"user"`
+	Client types.String `tfsdk:"client"`
+	AtTime types.String `tfsdk:"at_time"`
+}
+
+func (dsc *commitAtPendingDataSource) Read(
+	ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse,
+) {
+	var data commitAtPendingDataSourceData
+
+	var _ dataSourceDataReadWithoutArg = &data
+	defaultDataSourceRead(
+		ctx,
+		dsc,
+		nil,
+		&data,
+		resp,
+	)
+}
+
+func (dscData *commitAtPendingDataSourceData) fillID() {
+	dscData.ID = types.StringValue("commit_at_pending")
+}
+
+func (dscData *commitAtPendingDataSourceData) read(
+	ctx context.Context, junSess *junos.Session,
+) error {
+	output, err := junSess.Command(ctx, "show system commit")
+	if err != nil {
+		return err
+	}
+	dscData.fillCommits(output)
+
+	return nil
+}
+
+// fillCommits read the lines `commit requested by <user> via <client> at <time>`
+// in output of `show system commit` (with the lines `<re-name>:` before outputs of each Routing Engine).
+func (dscData *commitAtPendingDataSourceData) fillCommits(output string) {
+	commitRequested := regexp.MustCompile(`^commit requested by (\S+) via (\S+) at (.+)$`)
+	reName := regexp.MustCompile(`^(\S+):$`)
+
+	var currentReName string
+	for line := range strings.SplitSeq(output, "\n") {
+		line = strings.TrimSpace(line)
+		if v := reName.FindStringSubmatch(line); v != nil {
+			currentReName = v[1]
+
+			continue
+		}
+		v := commitRequested.FindStringSubmatch(line)
+		if v == nil {
+			continue
+		}
+		commit := commitAtPendingDataSourceBlockCommit{
+			User:   types.StringValue(v[1]),
+			Client: types.StringValue(v[2]),
+			AtTime: types.StringValue(v[3]),
+		}
+		if currentReName != "" {
+			commit.ReName = types.StringValue(currentReName)
+		}
+		dscData.Commits = append(dscData.Commits, commit)
+	}
+}
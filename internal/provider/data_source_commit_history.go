@@ -0,0 +1,314 @@
+package provider
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+	"github.com/jeremmfr/terraform-provider-junos/internal/tfvalidator"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &commitHistoryDataSource{}
+	_ datasource.DataSourceWithConfigure = &commitHistoryDataSource{}
+)
+
+type commitHistoryDataSource struct {
+	client *junos.Client
+}
+
+func (dsc *commitHistoryDataSource) typeName() string {
+	return providerName + "_commit_history"
+}
+
+func (dsc *commitHistoryDataSource) junosName() string {
+	return "system commit"
+}
+
+func (dsc *commitHistoryDataSource) junosClient() *junos.Client {
+	return dsc.client
+}
+
+func newCommitHistoryDataSource() datasource.DataSource {
+	return &commitHistoryDataSource{}
+}
+
+func (dsc *commitHistoryDataSource) Metadata(
+	_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = dsc.typeName()
+}
+
+func (dsc *commitHistoryDataSource) Configure(
+	ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*junos.Client)
+	if !ok {
+		unexpectedDataSourceConfigureType(ctx, req, resp)
+
+		return
+	}
+	dsc.client = client
+}
+
+func (dsc *commitHistoryDataSource) Schema(
+	_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Get the history of commits (" + dsc.junosName() + ")" +
+			" and the differences between two rollback configurations.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "An identifier for the data source with value `commit_history`.",
+			},
+			"diff_rollback": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Index of rollback configuration to compare with `diff_compare`.",
+				Validators: []validator.Int64{
+					int64validator.Between(0, 49),
+					int64validator.AlsoRequires(path.MatchRoot("diff_compare")),
+				},
+			},
+			"diff_compare": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Index of rollback configuration to compare `diff_rollback` with.",
+				Validators: []validator.Int64{
+					int64validator.Between(0, 49),
+					int64validator.AlsoRequires(path.MatchRoot("diff_rollback")),
+				},
+			},
+			"user_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only commits with user matching this regular expression.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					tfvalidator.StringRegex(),
+				},
+			},
+			"log_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only commits with log message matching this regular expression.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					tfvalidator.StringRegex(),
+				},
+			},
+			"commits": schema.ListAttribute{
+				Computed:    true,
+				Description: "For each commit in history (matching the filters), from the most recent.",
+				ElementType: types.ObjectType{}.WithAttributeTypes(map[string]attr.Type{
+					"re_name":   types.StringType,
+					"rollback":  types.Int64Type,
+					"date_time": types.StringType,
+					"timestamp": types.StringType,
+					"user":      types.StringType,
+					"client":    types.StringType,
+					"log":       types.StringType,
+					"comment":   types.StringType,
+				}),
+			},
+			"diff": schema.StringAttribute{
+				Computed: true,
+				Description: "Differences between the rollback configurations `diff_rollback` and `diff_compare`" +
+					" (like `show system rollback <diff_rollback> compare <diff_compare>`).",
+			},
+		},
+	}
+}
+
+type commitHistoryDataSourceData struct {
+	ID           types.String                         `tfsdk:"id"`
+	DiffRollback types.Int64                          `tfsdk:"diff_rollback"`
+	DiffCompare  types.Int64                          `tfsdk:"diff_compare"`
+	UserRegex    types.String                         `tfsdk:"user_regex"`
+	LogRegex     types.String                         `tfsdk:"log_regex"`
+	Commits      []commitHistoryDataSourceBlockCommit `tfsdk:"commits"`
+	Diff         types.String                         `tfsdk:"diff"`
+}
+
+type commitHistoryDataSourceBlockCommit struct {
+	ReName    types.String `tfsdk:"re_name"`
+	Rollback  types.Int64  `tfsdk:"rollback"`
+	DateTime  types.String `tfsdk:"date_time"`
+	Timestamp types.String `tfsdk:"timestamp"`
+	User      types.String `tfsdk:"user"`
+	Client    types.String `tfsdk:"client"`
+	Log       types.String `tfsdk:"log"`
+	Comment   types.String `tfsdk:"comment"`
+}
+
+func (dsc *commitHistoryDataSource) Read(
+	ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse,
+) {
+	var data commitHistoryDataSourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var _ dataSourceDataReadWithoutArg = &data
+	defaultDataSourceRead(
+		ctx,
+		dsc,
+		nil,
+		&data,
+		resp,
+	)
+}
+
+func (dscData *commitHistoryDataSourceData) fillID() {
+	dscData.ID = types.StringValue("commit_history")
+}
+
+func (dscData *commitHistoryDataSourceData) read(
+	ctx context.Context, junSess *junos.Session,
+) error {
+	var userRegexp, logRegexp *regexp.Regexp
+	if v := dscData.UserRegex.ValueString(); v != "" {
+		var err error
+		if userRegexp, err = regexp.Compile(v); err != nil {
+			return fmt.Errorf("compiling regexp '%s': %w", v, err)
+		}
+	}
+	if v := dscData.LogRegex.ValueString(); v != "" {
+		var err error
+		if logRegexp, err = regexp.Compile(v); err != nil {
+			return fmt.Errorf("compiling regexp '%s': %w", v, err)
+		}
+	}
+	commitInformations, err := readCommitInformation(ctx, junSess)
+	if err != nil {
+		return err
+	}
+	for _, commitInformation := range commitInformations {
+		for _, commitInfo := range commitInformation.reply.CommitHistory {
+			if userRegexp != nil && !userRegexp.MatchString(commitInfo.User) {
+				continue
+			}
+			if logRegexp != nil && !logRegexp.MatchString(strings.TrimSpace(commitInfo.Log)) {
+				continue
+			}
+			commit := commitHistoryDataSourceBlockCommit{
+				Rollback: types.Int64Value(int64(commitInfo.SequenceNumber)),
+				DateTime: types.StringValue(strings.TrimSpace(commitInfo.DateTime.Value)),
+				User:     types.StringValue(commitInfo.User),
+				Client:   types.StringValue(commitInfo.Client),
+			}
+			if v := commitInfo.DateTime.Seconds; v > 0 {
+				commit.Timestamp = types.StringValue(time.Unix(v, 0).UTC().Format(time.RFC3339))
+			}
+			if commitInformation.reName != "" {
+				commit.ReName = types.StringValue(commitInformation.reName)
+			}
+			if v := strings.TrimSpace(commitInfo.Log); v != "" {
+				commit.Log = types.StringValue(v)
+			}
+			if v := strings.TrimSpace(commitInfo.Comment); v != "" {
+				commit.Comment = types.StringValue(v)
+			}
+			dscData.Commits = append(dscData.Commits, commit)
+		}
+	}
+
+	if !dscData.DiffRollback.IsNull() && !dscData.DiffCompare.IsNull() {
+		replyData, err := junSess.CommandXML(ctx, fmt.Sprintf(junos.RPCGetRollbackInformationCompare,
+			dscData.DiffRollback.ValueInt64(), dscData.DiffCompare.ValueInt64()))
+		if err != nil {
+			return err
+		}
+		if strings.Contains(replyData, "<multi-routing-engine-results") {
+			type multiRollbackInformation struct {
+				XMLName                xml.Name `xml:"multi-routing-engine-results"`
+				MultiRoutingEngineItem []struct {
+					RollbackInformation junos.RPCGetRollbackInformationReply `xml:"rollback-information"`
+				} `xml:"multi-routing-engine-item"`
+			}
+
+			var reply multiRollbackInformation
+			if err := xml.Unmarshal([]byte(replyData), &reply); err != nil {
+				return fmt.Errorf("unmarshaling xml reply '%s': %w", replyData, err)
+			}
+			// the configuration is synchronized between routing engines, use the first one
+			if len(reply.MultiRoutingEngineItem) > 0 {
+				dscData.Diff = types.StringValue(
+					strings.TrimSpace(reply.MultiRoutingEngineItem[0].RollbackInformation.ConfigurationOutput),
+				)
+			}
+		} else {
+			var reply junos.RPCGetRollbackInformationReply
+			if err := xml.Unmarshal([]byte(replyData), &reply); err != nil {
+				return fmt.Errorf("unmarshaling xml reply '%s': %w", replyData, err)
+			}
+			dscData.Diff = types.StringValue(strings.TrimSpace(reply.ConfigurationOutput))
+		}
+	}
+
+	return nil
+}
+
+type commitInformationRoutingEngine struct {
+	reName string
+	reply  junos.RPCGetCommitInformationReply
+}
+
+// readCommitInformation read the commit history of each routing engine
+// (with the name of routing engine only if there are multiple routing engines).
+func readCommitInformation(
+	ctx context.Context, junSess *junos.Session,
+) (
+	[]commitInformationRoutingEngine, error,
+) {
+	replyData, err := junSess.CommandXML(ctx, junos.RPCGetCommitInformation)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Contains(replyData, "<multi-routing-engine-results") {
+		type multiCommitInformation struct {
+			XMLName                xml.Name `xml:"multi-routing-engine-results"`
+			MultiRoutingEngineItem []struct {
+				CommitInformation junos.RPCGetCommitInformationReply `xml:"commit-information"`
+				ReName            string                             `xml:"re-name"`
+			} `xml:"multi-routing-engine-item"`
+		}
+
+		var reply multiCommitInformation
+		if err := xml.Unmarshal([]byte(replyData), &reply); err != nil {
+			return nil, fmt.Errorf("unmarshaling xml reply '%s': %w", replyData, err)
+		}
+		commitInformations := make([]commitInformationRoutingEngine, len(reply.MultiRoutingEngineItem))
+		for i, item := range reply.MultiRoutingEngineItem {
+			commitInformations[i] = commitInformationRoutingEngine{
+				reName: item.ReName,
+				reply:  item.CommitInformation,
+			}
+		}
+
+		return commitInformations, nil
+	}
+
+	var reply junos.RPCGetCommitInformationReply
+	if err := xml.Unmarshal([]byte(replyData), &reply); err != nil {
+		return nil, fmt.Errorf("unmarshaling xml reply '%s': %w", replyData, err)
+	}
+
+	return []commitInformationRoutingEngine{{reply: reply}}, nil
+}
@@ -0,0 +1,370 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+	"github.com/jeremmfr/terraform-provider-junos/internal/tfdiag"
+	"github.com/jeremmfr/terraform-provider-junos/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &configImportDataSource{}
+	_ datasource.DataSourceWithConfigure = &configImportDataSource{}
+)
+
+// configImportDataSource generate the import blocks and the configuration of resources
+// found in the configuration of device.
+//
+// It's not wrapped by dataSourceWithDevice as it needs the name of device
+// for the import IDs and the generated configuration.
+type configImportDataSource struct {
+	client    *junos.Client
+	resources []func() resource.Resource
+}
+
+func (dsc *configImportDataSource) typeName() string {
+	return providerName + "_config_import"
+}
+
+func newConfigImportDataSource(resources []func() resource.Resource) func() datasource.DataSource {
+	return func() datasource.DataSource {
+		return &configImportDataSource{
+			resources: resources,
+		}
+	}
+}
+
+func (dsc *configImportDataSource) Metadata(
+	_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = dsc.typeName()
+}
+
+func (dsc *configImportDataSource) Configure(
+	ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*junos.Client)
+	if !ok {
+		unexpectedDataSourceConfigureType(ctx, req, resp)
+
+		return
+	}
+	dsc.client = client
+}
+
+func (dsc *configImportDataSource) Schema(
+	_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Generate the import blocks and the configuration of resources" +
+			" found in the committed configuration of the Junos device" +
+			" and get the lines of configuration not covered by these resources.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "An identifier for the data source with value `config_import`.",
+			},
+			deviceAttrName: schema.StringAttribute{
+				Optional: true,
+				Description: "Name of device in `devices` argument of provider" +
+					" to read data instead of the device of provider.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"resource_types": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Only generate the resources of these types." +
+					" Defaults to all types of resources with a list resource.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.OneOf(slices.Sorted(maps.Keys(configListResourceIDs))...),
+					),
+				},
+			},
+			"resources": schema.ListAttribute{
+				Computed:    true,
+				Description: "For each resource found in the configuration.",
+				ElementType: types.ObjectType{}.WithAttributeTypes(map[string]attr.Type{
+					"address": types.StringType,
+					"type":    types.StringType,
+					"id":      types.StringType,
+				}),
+			},
+			"config": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				Description: "Terraform configuration with the `import` block" +
+					" and the `resource` block of each resource found in the configuration" +
+					" (the values of sensitive arguments are replaced by a comment).",
+			},
+			"leftover_lines": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Set lines of the configuration not covered by the generated resources.",
+			},
+		},
+	}
+}
+
+type configImportDataSourceData struct {
+	ID            types.String                          `tfsdk:"id"`
+	Device        types.String                          `tfsdk:"device"`
+	ResourceTypes []types.String                        `tfsdk:"resource_types"`
+	Resources     []configImportDataSourceBlockResource `tfsdk:"resources"`
+	Config        types.String                          `tfsdk:"config"`
+	LeftoverLines []types.String                        `tfsdk:"leftover_lines"`
+}
+
+type configImportDataSourceBlockResource struct {
+	Address types.String `tfsdk:"address"`
+	Type    types.String `tfsdk:"type"`
+	ID      types.String `tfsdk:"id"`
+}
+
+func (dsc *configImportDataSource) Read(
+	ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse,
+) {
+	var data configImportDataSourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client, err := dsc.client.DeviceClient(data.Device.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root(deviceAttrName), "Device Not Found", err.Error())
+
+		return
+	}
+
+	junSess, err := client.StartNewSession(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(tfdiag.StartSessErrSummary, err.Error())
+
+		return
+	}
+	config, err := junSess.ConfigGet(ctx, junos.ConfigFormatSet)
+	junSess.Close()
+	if err != nil {
+		resp.Diagnostics.AddError(tfdiag.ConfigReadErrSummary, err.Error())
+
+		return
+	}
+	configLines := configSetLines(config)
+	// the resources are read in the configuration already read
+	// instead of opening a new session for each resource
+	snapshotClient := client.NewConfigSnapshotClient(junSess, config)
+
+	typeNames := slices.Sorted(maps.Keys(configListResourceIDs))
+	if len(data.ResourceTypes) > 0 {
+		typeNames = make([]string, 0, len(data.ResourceTypes))
+		for _, v := range data.ResourceTypes {
+			typeNames = append(typeNames, v.ValueString())
+		}
+		slices.Sort(typeNames)
+	}
+	resources := make(map[string]*resourceWithDevice)
+	for _, newResource := range dsc.resources {
+		if rsc, ok := unwrapResourceWithDevice(newResource()); ok {
+			resources[resourceTypeName(rsc)] = rsc
+		}
+	}
+
+	var importConfig strings.Builder
+	generatedLines := make([]string, 0)
+	data.Resources = make([]configImportDataSourceBlockResource, 0)
+	for _, typeName := range typeNames {
+		rsc, ok := resources[typeName]
+		if !ok {
+			continue
+		}
+		names := make(map[string]struct{})
+		for _, id := range configListIDs(typeName, configLines) {
+			innerSchema, state, diags := rsc.importState(ctx, snapshotClient, id)
+			var body strings.Builder
+			if !diags.HasError() {
+				if v := data.Device.ValueString(); v != "" {
+					body.WriteString("  " + deviceAttrName + " = " + hclQuote(v) + "\n\n")
+				}
+				if err := hclBlockBody(&body, "  ", innerSchema.Attributes, innerSchema.Blocks, state); err != nil {
+					diags.AddError("Generate Config Error", err.Error())
+				}
+			}
+			if diags.HasError() {
+				for _, d := range diags.Errors() {
+					resp.Diagnostics.AddWarning(
+						"Import Error",
+						fmt.Sprintf("resource %s with ID %q is not generated: %s: %s", typeName, id, d.Summary(), d.Detail()),
+					)
+				}
+
+				continue
+			}
+			name := hclResourceName(id)
+			for i := 2; ; i++ {
+				if _, ok := names[name]; !ok {
+					break
+				}
+				name = hclResourceName(id) + "_" + fmt.Sprint(i)
+			}
+			names[name] = struct{}{}
+
+			importID := id
+			if v := data.Device.ValueString(); v != "" {
+				importID = deviceImportIDPrefix + v + "/" + id
+			}
+			importConfig.WriteString("import {\n")
+			importConfig.WriteString("  to = " + typeName + "." + name + "\n")
+			importConfig.WriteString("  id = " + hclQuote(importID) + "\n")
+			importConfig.WriteString("}\n\n")
+			importConfig.WriteString("resource \"" + typeName + "\" \"" + name + "\" {\n")
+			importConfig.WriteString(body.String())
+			importConfig.WriteString("}\n\n")
+
+			data.Resources = append(data.Resources, configImportDataSourceBlockResource{
+				Address: types.StringValue(typeName + "." + name),
+				Type:    types.StringValue(typeName),
+				ID:      types.StringValue(id),
+			})
+			// lines not generated are not covered
+			rscLines, _ := rsc.stateLines(ctx, snapshotClient, innerSchema, state)
+			generatedLines = append(generatedLines, rscLines...)
+		}
+	}
+
+	data.ID = types.StringValue("config_import")
+	data.Config = types.StringValue(strings.TrimSuffix(importConfig.String(), "\n"))
+	data.LeftoverLines = make([]types.String, 0)
+	for _, line := range configLinesNotCovered(configLines, generatedLines) {
+		data.LeftoverLines = append(data.LeftoverLines, types.StringValue(line))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// unwrapResourceWithDevice return the resourceWithDevice of a resource returned by resourcesWithDevice.
+func unwrapResourceWithDevice(rsc resource.Resource) (*resourceWithDevice, bool) {
+	switch v := rsc.(type) {
+	case *resourceWithDevice:
+		return v, true
+	case *resourceWithDeviceIdentity:
+		return v.resourceWithDevice, true
+	}
+
+	return nil, false
+}
+
+// configSetLines return the set (and deactivate) lines of a configuration in set format.
+func configSetLines(config string) []string {
+	lines := make([]string, 0)
+	for line := range strings.SplitSeq(config, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		// the version is not a configuration to manage
+		case strings.HasPrefix(line, "set version "):
+		case strings.HasPrefix(line, "set "), strings.HasPrefix(line, "deactivate "):
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// configLinesNotCovered return the lines of configuration not covered by the lines generated by resources.
+//
+// A line of configuration is covered if a generated line is the same line or a line under it
+// (when the configuration is displayed, a statement is only displayed on the line of its last child).
+func configLinesNotCovered(configLines, generatedLines []string) []string {
+	covered := make(map[string]struct{})
+	for _, line := range generatedLines {
+		if !strings.HasPrefix(line, "set ") {
+			continue
+		}
+		words := configLineNormalizedWords(line)
+		for i := 2; i <= len(words); i++ {
+			covered[strings.Join(words[:i], " ")] = struct{}{}
+		}
+	}
+	notCovered := make([]string, 0)
+	for _, line := range configLines {
+		words := configLineNormalizedWords(line)
+		if _, ok := covered[strings.Join(words, " ")]; ok {
+			continue
+		}
+		// a secret is read decoded by resources (without no_decode_secrets),
+		// so the secret generated in the line isn't the encrypted one of configuration
+		if n := len(words); n > 2 && configWordEncryptedSecret(words[n-1]) {
+			if _, ok := covered[strings.Join(words[:n-1], " ")]; ok {
+				continue
+			}
+		}
+		notCovered = append(notCovered, line)
+	}
+
+	return notCovered
+}
+
+// configLineNormalizedWords return the words of a set line with the values normalized
+// like when the configuration is displayed by the device
+// (an IP address or a MAC address is displayed in its canonical form).
+func configLineNormalizedWords(line string) []string {
+	words := utils.ConfigLineWords(line)
+	for i, word := range words {
+		if strings.HasPrefix(word, `"`) {
+			continue
+		}
+		if addr, err := netip.ParseAddr(word); err == nil {
+			words[i] = addr.String()
+
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(word); err == nil {
+			words[i] = prefix.String()
+
+			continue
+		}
+		if mac, err := net.ParseMAC(word); err == nil && len(mac) == 6 {
+			words[i] = mac.String()
+		}
+	}
+
+	return words
+}
+
+// configWordEncryptedSecret return true if the word of a line is an encrypted secret
+// ($9$ or crypt hashes).
+func configWordEncryptedSecret(word string) bool {
+	word = strings.Trim(word, "\"")
+	for _, prefix := range []string{"$9$", "$1$", "$5$", "$6$"} {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"fmt"
+	"maps"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var hclIdentifierRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+// hclResourceName return a name of resource for Terraform configuration generated from the ID of resource.
+func hclResourceName(id string) string {
+	var name strings.Builder
+	for _, r := range strings.ReplaceAll(id, "_-_", "_") {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+			name.WriteRune(r)
+		default:
+			name.WriteRune('_')
+		}
+	}
+	if !hclIdentifierRegexp.MatchString(name.String()) {
+		return "_" + name.String()
+	}
+
+	return name.String()
+}
+
+// hclBlockBody write the body of a block in Terraform configuration
+// with the arguments (attributes not only computed and blocks) of schema with a value.
+//
+// The values of sensitive attributes are not written, only a comment with the argument to set.
+func hclBlockBody(
+	body *strings.Builder, indent string, attributes map[string]schema.Attribute, blocks map[string]schema.Block,
+	value tftypes.Value,
+) error {
+	var values map[string]tftypes.Value
+	if err := value.As(&values); err != nil {
+		return fmt.Errorf("reading object value: %w", err)
+	}
+
+	// consecutive arguments on a single line are aligned like with `terraform fmt`
+	var argLines [][2]string
+	writeArgLines := func() {
+		width := 0
+		for _, v := range argLines {
+			width = max(width, len(v[0]))
+		}
+		for _, v := range argLines {
+			body.WriteString(indent + v[0] + strings.Repeat(" ", width-len(v[0])) + " = " + v[1] + "\n")
+		}
+		argLines = argLines[:0]
+	}
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		attribute := attributes[name]
+		v, ok := values[name]
+		if !ok || v.IsNull() || !v.IsKnown() ||
+			(attribute.IsComputed() && !attribute.IsOptional() && !attribute.IsRequired()) {
+			continue
+		}
+		if attribute.IsSensitive() {
+			writeArgLines()
+			body.WriteString(indent + "# " + name + " = (sensitive value to set)\n")
+
+			continue
+		}
+		expr, err := hclExpression(v, indent)
+		if err != nil {
+			return fmt.Errorf("attribute %s: %w", name, err)
+		}
+		if strings.Contains(expr, "\n") {
+			writeArgLines()
+			body.WriteString(indent + name + " = " + expr + "\n")
+
+			continue
+		}
+		argLines = append(argLines, [2]string{name, expr})
+	}
+	writeArgLines()
+
+	for _, name := range slices.Sorted(maps.Keys(blocks)) {
+		v, ok := values[name]
+		if !ok || v.IsNull() || !v.IsKnown() {
+			continue
+		}
+		var (
+			nestedAttributes map[string]schema.Attribute
+			nestedBlocks     map[string]schema.Block
+			elements         []tftypes.Value
+		)
+		switch block := blocks[name].(type) {
+		case schema.ListNestedBlock:
+			nestedAttributes, nestedBlocks = block.NestedObject.Attributes, block.NestedObject.Blocks
+			if err := v.As(&elements); err != nil {
+				return fmt.Errorf("block %s: %w", name, err)
+			}
+		case schema.SetNestedBlock:
+			nestedAttributes, nestedBlocks = block.NestedObject.Attributes, block.NestedObject.Blocks
+			if err := v.As(&elements); err != nil {
+				return fmt.Errorf("block %s: %w", name, err)
+			}
+		case schema.SingleNestedBlock:
+			nestedAttributes, nestedBlocks = block.Attributes, block.Blocks
+			elements = []tftypes.Value{v}
+		default:
+			return fmt.Errorf("block %s: unsupported type of block %T", name, block)
+		}
+		for _, element := range elements {
+			body.WriteString(indent + name + " {\n")
+			if err := hclBlockBody(body, indent+"  ", nestedAttributes, nestedBlocks, element); err != nil {
+				return fmt.Errorf("block %s: %w", name, err)
+			}
+			body.WriteString(indent + "}\n")
+		}
+	}
+
+	return nil
+}
+
+// hclExpression return the expression in Terraform configuration of a value.
+func hclExpression(value tftypes.Value, indent string) (string, error) {
+	if value.IsNull() {
+		return "null", nil
+	}
+	typ := value.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var v string
+		if err := value.As(&v); err != nil {
+			return "", err
+		}
+
+		return hclQuote(v), nil
+	case typ.Is(tftypes.Number):
+		var v big.Float
+		if err := value.As(&v); err != nil {
+			return "", err
+		}
+
+		return v.Text('f', -1), nil
+	case typ.Is(tftypes.Bool):
+		var v bool
+		if err := value.As(&v); err != nil {
+			return "", err
+		}
+
+		return strconv.FormatBool(v), nil
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return "", err
+		}
+		exprs := make([]string, len(elements))
+		multiline := false
+		for i, element := range elements {
+			expr, err := hclExpression(element, indent+"  ")
+			if err != nil {
+				return "", err
+			}
+			exprs[i] = expr
+			multiline = multiline || strings.Contains(expr, "\n")
+		}
+		if multiline {
+			return "[\n" + indent + "  " + strings.Join(exprs, ",\n"+indent+"  ") + ",\n" + indent + "]", nil
+		}
+
+		return "[" + strings.Join(exprs, ", ") + "]", nil
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		var elements map[string]tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return "", err
+		}
+		var expr strings.Builder
+		expr.WriteString("{\n")
+		for _, k := range slices.Sorted(maps.Keys(elements)) {
+			if elements[k].IsNull() {
+				continue
+			}
+			v, err := hclExpression(elements[k], indent+"  ")
+			if err != nil {
+				return "", err
+			}
+			if !hclIdentifierRegexp.MatchString(k) {
+				k = hclQuote(k)
+			}
+			expr.WriteString(indent + "  " + k + " = " + v + "\n")
+		}
+		expr.WriteString(indent + "}")
+
+		return expr.String(), nil
+	}
+
+	return "", fmt.Errorf("unsupported type of value %s", typ)
+}
+
+// hclQuote return the string quoted in Terraform configuration
+// with the escape sequences of HCL and without template sequences.
+func hclQuote(s string) string {
+	var quoted strings.Builder
+	quoted.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '\n':
+			quoted.WriteString(`\n`)
+		case r == '\r':
+			quoted.WriteString(`\r`)
+		case r == '\t':
+			quoted.WriteString(`\t`)
+		case r == '"', r == '\\':
+			quoted.WriteByte('\\')
+			quoted.WriteRune(r)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			quoted.WriteRune(r)
+			quoted.WriteRune(r)
+		case r > 0xffff && !unicode.IsPrint(r):
+			fmt.Fprintf(&quoted, `\U%08x`, r)
+		case !unicode.IsPrint(r):
+			fmt.Fprintf(&quoted, `\u%04x`, r)
+		default:
+			quoted.WriteRune(r)
+		}
+	}
+	quoted.WriteByte('"')
+
+	return quoted.String()
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestConfigLinesNotCovered(t *testing.T) {
+	t.Parallel()
+
+	configLines := []string{
+		"set applications application app1 protocol tcp",
+		"set applications application app1 destination-port 22",
+		"set applications application \"app 2\" protocol udp",
+		"set interfaces ge-0/0/3 unit 0 family inet",
+		"set interfaces ge-0/0/4 mac 00:05:85:aa:bb:cc",
+		"set system host-name router1",
+		"set system name-server 2001:db8::53",
+		"set system radius-server 192.0.2.10 secret \"$9$dsb2aZjHmPQ\"",
+		"set system tacplus-server 192.0.2.11 secret \"$9$dsb2aZjHmPQ\"",
+	}
+	generatedLines := []string{
+		"delete applications application app1",
+		"set applications application app1 protocol tcp",
+		"set applications application app1 destination-port \"22\"",
+		"set applications application \"app 2\" protocol \"udp\"",
+		"set interfaces ge-0/0/3 unit 0 family inet address 192.0.2.1/24",
+		"set interfaces ge-0/0/4 mac 00:05:85:AA:BB:CC",
+		"set system name-server 2001:DB8:0:0::53",
+		"set system radius-server 192.0.2.10 secret \"decoded\"",
+	}
+	expect := []string{
+		"set system host-name router1",
+		"set system tacplus-server 192.0.2.11 secret \"$9$dsb2aZjHmPQ\"",
+	}
+	if v := configLinesNotCovered(configLines, generatedLines); !slices.Equal(v, expect) {
+		t.Errorf("got unexpected lines not covered: %q, want %q", v, expect)
+	}
+}
+
+func TestHCLQuote(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"simple":        `"simple"`,
+		"a \"b\" \\c":   `"a \"b\" \\c"`,
+		"l1\nl2\r\tend": `"l1\nl2\r\tend"`,
+		"${var} %{if}":  `"$${var} %%{if}"`,
+		"$ % {":         `"$ % {"`,
+		"nul\x00bel\a":  `"nul\u0000bel\u0007"`,
+		"\u00e9t\u00e9": `"été"`,
+		"\U000e0001":    `"\U000e0001"`,
+	}
+	for value, expect := range tests {
+		if v := hclQuote(value); v != expect {
+			t.Errorf("got %s for %q, expected %s", v, value, expect)
+		}
+	}
+}
+
+func TestHCLBlockBodySensitive(t *testing.T) {
+	t.Parallel()
+
+	attributes := map[string]schema.Attribute{
+		"name":               schema.StringAttribute{Required: true},
+		"authentication_key": schema.StringAttribute{Optional: true, Sensitive: true},
+		"description":        schema.StringAttribute{Optional: true},
+	}
+	value := tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name":               tftypes.String,
+		"authentication_key": tftypes.String,
+		"description":        tftypes.String,
+	}}, map[string]tftypes.Value{
+		"name":               tftypes.NewValue(tftypes.String, "vrrp1"),
+		"authentication_key": tftypes.NewValue(tftypes.String, "clear-text-secret"),
+		"description":        tftypes.NewValue(tftypes.String, "group 1"),
+	})
+	var body strings.Builder
+	if err := hclBlockBody(&body, "  ", attributes, nil, value); err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+	expect := "  # authentication_key = (sensitive value to set)\n" +
+		"  description = \"group 1\"\n" +
+		"  name        = \"vrrp1\"\n"
+	if v := body.String(); v != expect {
+		t.Errorf("got unexpected body:\n%s\nwant:\n%s", v, expect)
+	}
+}
+
+func TestConfigImportDataSourceWithSimulator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, client := newSimulatorClient(t,
+		"set applications application app1 protocol tcp",
+		"set applications application app1 destination-port 22",
+		"set applications application-set set1 application app1",
+		"set system host-name router1",
+	)
+	dsc := newTestDataSource(ctx, newConfigImportDataSource(resourcesWithDevice([]func() resource.Resource{
+		newApplicationResource,
+		newApplicationSetResource,
+	}))(), client)
+
+	readResp := dsc.read(ctx, t, &configImportDataSourceData{})
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("got unexpected error on read: %v", readResp.Diagnostics)
+	}
+	if len(readResp.Diagnostics) > 0 {
+		t.Errorf("got unexpected diagnostics on read: %v", readResp.Diagnostics)
+	}
+
+	var data configImportDataSourceData
+	readResp.State.Get(ctx, &data)
+	expectConfig := `import {
+  to = junos_application.app1
+  id = "app1"
+}
+
+resource "junos_application" "app1" {
+  destination_port = "22"
+  name             = "app1"
+  protocol         = "tcp"
+}
+
+import {
+  to = junos_application_set.set1
+  id = "set1"
+}
+
+resource "junos_application_set" "set1" {
+  applications = ["app1"]
+  name         = "set1"
+}
+`
+	if v := data.Config.ValueString(); v != expectConfig {
+		t.Errorf("got unexpected config:\n%s\nwant:\n%s", v, expectConfig)
+	}
+	if len(data.Resources) != 2 ||
+		data.Resources[0].Address.ValueString() != "junos_application.app1" ||
+		data.Resources[1].Address.ValueString() != "junos_application_set.set1" {
+		t.Errorf("got unexpected resources: %v", data.Resources)
+	}
+	if len(data.LeftoverLines) != 1 ||
+		data.LeftoverLines[0].ValueString() != "set system host-name router1" {
+		t.Errorf("got unexpected leftover lines: %v", data.LeftoverLines)
+	}
+}
@@ -0,0 +1,301 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+	"github.com/jeremmfr/terraform-provider-junos/internal/tfdiag"
+	"github.com/jeremmfr/terraform-provider-junos/internal/tfvalidator"
+	"github.com/jeremmfr/terraform-provider-junos/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &configUnmanagedDataSource{}
+	_ datasource.DataSourceWithConfigure = &configUnmanagedDataSource{}
+)
+
+const configUnmanagedDefaultHierarchyDepth = 2
+
+type configUnmanagedDataSource struct {
+	client    *junos.Client
+	resources []func() resource.Resource
+}
+
+func (dsc *configUnmanagedDataSource) typeName() string {
+	return providerName + "_config_unmanaged"
+}
+
+func newConfigUnmanagedDataSource(resources []func() resource.Resource) func() datasource.DataSource {
+	return func() datasource.DataSource {
+		return &configUnmanagedDataSource{
+			resources: resources,
+		}
+	}
+}
+
+func (dsc *configUnmanagedDataSource) Metadata(
+	_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = dsc.typeName()
+}
+
+func (dsc *configUnmanagedDataSource) Configure(
+	ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*junos.Client)
+	if !ok {
+		unexpectedDataSourceConfigureType(ctx, req, resp)
+
+		return
+	}
+	dsc.client = client
+}
+
+func (dsc *configUnmanagedDataSource) Schema(
+	_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Get the lines of the committed configuration of the Junos device" +
+			" not covered by the lines generated by a list of resources (the unmanaged configuration).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "An identifier for the data source with value `config_unmanaged`.",
+			},
+			"ignore_lines_regex": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Ignore the unmanaged lines matching one of these regular expressions.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.LengthAtLeast(1),
+						tfvalidator.StringRegex(),
+					),
+				},
+			},
+			"hierarchy_depth": schema.Int64Attribute{
+				Optional: true,
+				Description: "Number of words after `set` (or `deactivate`) in lines" +
+					" to group the unmanaged lines by hierarchy in `hierarchies`." +
+					" Defaults to `2`.",
+				Validators: []validator.Int64{
+					int64validator.Between(1, 10),
+				},
+			},
+			"lines": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Set (and deactivate) lines of the configuration not covered by the resources.",
+			},
+			"hierarchies": schema.ListAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "For each hierarchy with unmanaged lines.",
+				ElementType: types.ObjectType{}.WithAttributeTypes(map[string]attr.Type{
+					"hierarchy": types.StringType,
+					"lines":     types.ListType{}.WithElementType(types.StringType),
+				}),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"resource": schema.ListNestedBlock{
+				Description: "For each type of resources managing the configuration.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Required:    true,
+							Description: "Type of resources.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"ids": schema.SetAttribute{
+							ElementType: types.StringType,
+							Required:    true,
+							Description: "IDs of resources (the `id` attribute, like to import them).",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type configUnmanagedDataSourceData struct {
+	ID               types.String                                `tfsdk:"id"`
+	IgnoreLinesRegex []types.String                              `tfsdk:"ignore_lines_regex"`
+	HierarchyDepth   types.Int64                                 `tfsdk:"hierarchy_depth"`
+	Lines            []types.String                              `tfsdk:"lines"`
+	Hierarchies      []configUnmanagedDataSourceBlockHierarchies `tfsdk:"hierarchies"`
+	Resource         []configUnmanagedDataSourceBlockResource    `tfsdk:"resource"`
+}
+
+type configUnmanagedDataSourceBlockHierarchies struct {
+	Hierarchy types.String   `tfsdk:"hierarchy"`
+	Lines     []types.String `tfsdk:"lines"`
+}
+
+type configUnmanagedDataSourceBlockResource struct {
+	Type types.String   `tfsdk:"type"`
+	IDs  []types.String `tfsdk:"ids"`
+}
+
+func (dsc *configUnmanagedDataSource) Read(
+	ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse,
+) {
+	var data configUnmanagedDataSourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ignoreRegexps := make([]*regexp.Regexp, len(data.IgnoreLinesRegex))
+	for i, v := range data.IgnoreLinesRegex {
+		var err error
+		if ignoreRegexps[i], err = regexp.Compile(v.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ignore_lines_regex"),
+				"Bad Regular Expression",
+				fmt.Sprintf("compiling regexp '%s': %s", v.ValueString(), err),
+			)
+
+			return
+		}
+	}
+	resources := make(map[string]*resourceWithDevice)
+	for _, newResource := range dsc.resources {
+		if rsc, ok := unwrapResourceWithDevice(newResource()); ok {
+			resources[resourceTypeName(rsc)] = rsc
+		}
+	}
+	for i, block := range data.Resource {
+		if _, ok := resources[block.Type.ValueString()]; !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("resource").AtListIndex(i).AtName("type"),
+				"Unknown Resource Type",
+				fmt.Sprintf("resource type %q doesn't exist in provider", block.Type.ValueString()),
+			)
+
+			return
+		}
+	}
+
+	junSess, err := dsc.client.StartNewSession(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(tfdiag.StartSessErrSummary, err.Error())
+
+		return
+	}
+	config, err := junSess.ConfigGet(ctx, junos.ConfigFormatSet)
+	junSess.Close()
+	if err != nil {
+		resp.Diagnostics.AddError(tfdiag.ConfigReadErrSummary, err.Error())
+
+		return
+	}
+	// the resources are read in the configuration already read
+	// instead of opening a new session for each resource
+	snapshotClient := dsc.client.NewConfigSnapshotClient(junSess, config)
+
+	generatedLines := make([]string, 0)
+	for _, block := range data.Resource {
+		typeName := block.Type.ValueString()
+		rsc := resources[typeName]
+		for _, id := range block.IDs {
+			innerSchema, state, diags := rsc.importState(ctx, snapshotClient, id.ValueString())
+			if diags.HasError() {
+				for _, d := range diags.Errors() {
+					resp.Diagnostics.AddWarning(
+						"Import Error",
+						fmt.Sprintf("resource %s with ID %q is not read: %s: %s",
+							typeName, id.ValueString(), d.Summary(), d.Detail()),
+					)
+				}
+
+				continue
+			}
+			lines, ok := rsc.stateLines(ctx, snapshotClient, innerSchema, state)
+			if !ok {
+				resp.Diagnostics.AddWarning(
+					"Generate Lines Error",
+					fmt.Sprintf("set lines of resource %s with ID %q can't be generated without device",
+						typeName, id.ValueString()),
+				)
+
+				continue
+			}
+			generatedLines = append(generatedLines, lines...)
+		}
+	}
+
+	depth := configUnmanagedDefaultHierarchyDepth
+	if !data.HierarchyDepth.IsNull() {
+		depth = int(data.HierarchyDepth.ValueInt64())
+	}
+	data.ID = types.StringValue("config_unmanaged")
+	data.Lines = make([]types.String, 0)
+	data.Hierarchies = make([]configUnmanagedDataSourceBlockHierarchies, 0)
+	hierarchyIndex := make(map[string]int)
+	for _, line := range configLinesNotCovered(configSetLines(config), generatedLines) {
+		ignored := false
+		for _, re := range ignoreRegexps {
+			if re.MatchString(line) {
+				ignored = true
+
+				break
+			}
+		}
+		if ignored {
+			continue
+		}
+		data.Lines = append(data.Lines, types.StringValue(line))
+
+		hierarchy := configLineHierarchy(line, depth)
+		i, ok := hierarchyIndex[hierarchy]
+		if !ok {
+			i = len(data.Hierarchies)
+			hierarchyIndex[hierarchy] = i
+			data.Hierarchies = append(data.Hierarchies, configUnmanagedDataSourceBlockHierarchies{
+				Hierarchy: types.StringValue(hierarchy),
+			})
+		}
+		data.Hierarchies[i].Lines = append(data.Hierarchies[i].Lines, types.StringValue(line))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// configLineHierarchy return the first words (maximum depth) of statement in a set or deactivate line.
+func configLineHierarchy(line string, depth int) string {
+	words := utils.ConfigLineWords(line)
+	if len(words) > 0 {
+		// set or deactivate
+		words = words[1:]
+	}
+	if len(words) > depth {
+		words = words[:depth]
+	}
+
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestConfigUnmanagedDataSourceWithSimulator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, client := newSimulatorClient(t,
+		"set applications application app1 protocol tcp",
+		"set applications application app1 destination-port 22",
+		"set applications application app2 protocol udp",
+		"set applications application-set set1 application app1",
+		"set system host-name router1",
+		"set system services ssh",
+	)
+	dsc := newTestDataSource(ctx, newConfigUnmanagedDataSource(resourcesWithDevice([]func() resource.Resource{
+		newApplicationResource,
+	}))(), client)
+
+	read := func(data configUnmanagedDataSourceData) (configUnmanagedDataSourceData, bool) {
+		t.Helper()
+
+		readResp := dsc.read(ctx, t, &data)
+		if readResp.Diagnostics.HasError() {
+			return configUnmanagedDataSourceData{}, false
+		}
+		var result configUnmanagedDataSourceData
+		readResp.State.Get(ctx, &result)
+
+		return result, true
+	}
+
+	result, ok := read(configUnmanagedDataSourceData{
+		IgnoreLinesRegex: []types.String{types.StringValue("^set system host-name ")},
+		Resource: []configUnmanagedDataSourceBlockResource{{
+			Type: types.StringValue("junos_application"),
+			IDs:  []types.String{types.StringValue("app1")},
+		}},
+	})
+	if !ok {
+		t.Fatalf("got unexpected error on read")
+	}
+	var lines []string
+	for _, v := range result.Lines {
+		lines = append(lines, v.ValueString())
+	}
+	expectLines := []string{
+		"set applications application app2 protocol udp",
+		"set applications application-set set1 application app1",
+		"set system services ssh",
+	}
+	if !slices.Equal(lines, expectLines) {
+		t.Errorf("got unexpected lines: %q, want %q", lines, expectLines)
+	}
+	var hierarchies []string
+	for _, v := range result.Hierarchies {
+		hierarchies = append(hierarchies, v.Hierarchy.ValueString())
+	}
+	expectHierarchies := []string{
+		"applications application",
+		"applications application-set",
+		"system services",
+	}
+	if !slices.Equal(hierarchies, expectHierarchies) {
+		t.Errorf("got unexpected hierarchies: %q, want %q", hierarchies, expectHierarchies)
+	}
+
+	if _, ok := read(configUnmanagedDataSourceData{
+		Resource: []configUnmanagedDataSourceBlockResource{{
+			Type: types.StringValue("junos_unknown"),
+			IDs:  []types.String{},
+		}},
+	}); ok {
+		t.Errorf("expected error with unknown resource type")
+	}
+}
@@ -63,6 +63,13 @@ type resourceDataReadFrom2String1Bool1String interface {
 	read(context.Context, string, string, bool, string, *junos.Session) error
 }
 
+// resourceDataUnmanaged: data of resources with the authoritative argument
+// which read and remove the lines of their block of configuration not managed by them.
+type resourceDataUnmanaged interface {
+	readUnmanaged(context.Context, *junos.Session) error
+	delUnmanaged(context.Context, *junos.Session) error
+}
+
 type resourceDataReadComputed interface {
 	readComputed(context.Context, *junos.Session) error
 }
@@ -106,6 +113,27 @@ type junosResource interface {
 	typeName() string
 }
 
+// discardChangesOnError discard the uncommitted changes in candidate configuration
+// if there is an error in diagnostics (to not leave them to the next commit)
+// and add a warning with the discarded changes.
+func discardChangesOnError(ctx context.Context, junSess *junos.Session, diags *diag.Diagnostics) {
+	if !diags.HasError() {
+		return
+	}
+	changes, err := junSess.ConfigDiscard(ctx)
+	if err != nil {
+		diags.AddWarning(tfdiag.ConfigDiscardWarnSummary, err.Error())
+
+		return
+	}
+	if changes != "" {
+		diags.AddWarning(
+			tfdiag.ConfigDiscardWarnSummary,
+			"uncommitted changes discarded in candidate configuration after error:\n"+changes,
+		)
+	}
+}
+
 func defaultResourceCreate(
 	ctx context.Context,
 	rsc junosResource,
@@ -152,6 +180,7 @@ func defaultResourceCreate(
 		return
 	}
 	defer func() {
+		discardChangesOnError(ctx, junSess, &resp.Diagnostics)
 		resp.Diagnostics.Append(tfdiag.Warns(tfdiag.ConfigUnlockWarnSummary, junSess.ConfigUnlock(ctx))...)
 	}()
 
@@ -159,6 +188,13 @@ func defaultResourceCreate(
 		return
 	}
 
+	if planUnmanaged, ok := plan.(resourceDataUnmanaged); ok {
+		if err := planUnmanaged.delUnmanaged(ctx, junSess); err != nil {
+			resp.Diagnostics.AddError(tfdiag.ConfigDelErrSummary, err.Error())
+
+			return
+		}
+	}
 	if errPath, err := plan.set(ctx, junSess); err != nil {
 		if !errPath.Equal(path.Empty()) {
 			resp.Diagnostics.AddAttributeError(errPath, tfdiag.ConfigSetErrSummary, err.Error())
@@ -287,6 +323,9 @@ func defaultResourceRead(
 			junSess,
 		)
 	}
+	if dataUnmanaged, ok := data.(resourceDataUnmanaged); ok && err == nil {
+		err = dataUnmanaged.readUnmanaged(ctx, junSess)
+	}
 	junos.MutexUnlock()
 	if err != nil {
 		resp.Diagnostics.AddError(tfdiag.ConfigReadErrSummary, err.Error())
@@ -364,6 +403,7 @@ func defaultResourceUpdate(
 		return
 	}
 	defer func() {
+		discardChangesOnError(ctx, junSess, &resp.Diagnostics)
 		resp.Diagnostics.Append(tfdiag.Warns(tfdiag.ConfigUnlockWarnSummary, junSess.ConfigUnlock(ctx))...)
 	}()
 
@@ -380,6 +420,13 @@ func defaultResourceUpdate(
 			return
 		}
 	}
+	if planUnmanaged, ok := plan.(resourceDataUnmanaged); ok {
+		if err := planUnmanaged.delUnmanaged(ctx, junSess); err != nil {
+			resp.Diagnostics.AddError(tfdiag.ConfigDelErrSummary, err.Error())
+
+			return
+		}
+	}
 	if errPath, err := plan.set(ctx, junSess); err != nil {
 		if !errPath.Equal(path.Empty()) {
 			resp.Diagnostics.AddAttributeError(errPath, tfdiag.ConfigSetErrSummary, err.Error())
@@ -446,6 +493,7 @@ func defaultResourceDelete(
 		return
 	}
 	defer func() {
+		discardChangesOnError(ctx, junSess, &resp.Diagnostics)
 		resp.Diagnostics.Append(tfdiag.Warns(tfdiag.ConfigUnlockWarnSummary, junSess.ConfigUnlock(ctx))...)
 	}()
 
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/tfdiag"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDefaultResourceOpDiscardOnCommitErrorWithSimulator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	initConfig := []string{
+		"set applications application app1 protocol tcp",
+	}
+	srv, client := newSimulatorClient(t, initConfig...)
+	rsc := newTestResource(ctx, newApplicationResource, client)
+	srv.SetCommitError("configuration check-out failed")
+
+	// hasDiscardWarning return true if the diagnostics have a warning of discard with the line.
+	hasDiscardWarning := func(diags diag.Diagnostics, line string) bool {
+		for _, d := range diags.Warnings() {
+			if d.Summary() == tfdiag.ConfigDiscardWarnSummary && strings.Contains(d.Detail(), line) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	// create
+	plan := rsc.newPlan(ctx, t, &applicationData{
+		applicationAttrData: applicationAttrData{
+			Name:     types.StringValue("app2"),
+			Protocol: types.StringValue("udp"),
+		},
+	})
+	createResp := resource.CreateResponse{State: rsc.nullState(ctx)}
+	rsc.Create(ctx, resource.CreateRequest{
+		Config: planConfig(plan),
+		Plan:   plan,
+	}, &createResp)
+	if !createResp.Diagnostics.HasError() {
+		t.Fatalf("expected error on create with commit error but got none")
+	}
+	if !hasDiscardWarning(createResp.Diagnostics, "app2") {
+		t.Errorf("expected warning of discarded changes on create, got %v", createResp.Diagnostics)
+	}
+	if v := srv.Candidate(); !sameLines(v, initConfig) {
+		t.Errorf("got unexpected candidate after create in error: %q, want %q", v, initConfig)
+	}
+
+	// update
+	state := rsc.newState(ctx, t, &applicationData{
+		ID: types.StringValue("app1"),
+		applicationAttrData: applicationAttrData{
+			Name:     types.StringValue("app1"),
+			Protocol: types.StringValue("tcp"),
+		},
+	})
+	planUpdate := rsc.newPlan(ctx, t, &applicationData{
+		ID: types.StringValue("app1"),
+		applicationAttrData: applicationAttrData{
+			Name:        types.StringValue("app1"),
+			Protocol:    types.StringValue("tcp"),
+			Description: types.StringValue("app with description"),
+		},
+	})
+	updateResp := resource.UpdateResponse{State: state}
+	rsc.Update(ctx, resource.UpdateRequest{
+		Config: planConfig(planUpdate),
+		Plan:   planUpdate,
+		State:  state,
+	}, &updateResp)
+	if !updateResp.Diagnostics.HasError() {
+		t.Fatalf("expected error on update with commit error but got none")
+	}
+	if !hasDiscardWarning(updateResp.Diagnostics, "app with description") {
+		t.Errorf("expected warning of discarded changes on update, got %v", updateResp.Diagnostics)
+	}
+	if v := srv.Candidate(); !sameLines(v, initConfig) {
+		t.Errorf("got unexpected candidate after update in error: %q, want %q", v, initConfig)
+	}
+
+	if v := srv.Config(); !sameLines(v, initConfig) {
+		t.Errorf("got unexpected config: %q, want %q", v, initConfig)
+	}
+	if v := len(srv.Commits()); v != 0 {
+		t.Errorf("got %d commits, want 0", v)
+	}
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"maps"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action                   = &actionWithDevice{}
+	_ action.ActionWithConfigure      = &actionWithDevice{}
+	_ action.ActionWithValidateConfig = &actionWithDevice{}
+)
+
+// actionWithDevice wrap an action to add the `device` attribute
+// and use the client of selected device in devices of provider.
+//
+// Like resourceWithDevice, the `device` attribute is hidden to the wrapped action.
+type actionWithDevice struct {
+	inner  action.Action
+	client *junos.Client
+}
+
+func actionsWithDevice(actions []func() action.Action) []func() action.Action {
+	wrapped := make([]func() action.Action, len(actions))
+	for i, newAction := range actions {
+		wrapped[i] = func() action.Action {
+			return &actionWithDevice{
+				inner: newAction(),
+			}
+		}
+	}
+
+	return wrapped
+}
+
+func (act *actionWithDevice) Metadata(
+	ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse,
+) {
+	act.inner.Metadata(ctx, req, resp)
+}
+
+func (act *actionWithDevice) Configure(
+	ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse,
+) {
+	if client, ok := req.ProviderData.(*junos.Client); ok {
+		act.client = client
+	}
+	if inner, ok := act.inner.(action.ActionWithConfigure); ok {
+		inner.Configure(ctx, req, resp)
+	}
+}
+
+func (act *actionWithDevice) Schema(
+	ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse,
+) {
+	act.inner.Schema(ctx, req, resp)
+	resp.Schema.Attributes = maps.Clone(resp.Schema.Attributes)
+	if resp.Schema.Attributes == nil {
+		resp.Schema.Attributes = make(map[string]schema.Attribute)
+	}
+	resp.Schema.Attributes[deviceAttrName] = schema.StringAttribute{
+		Optional: true,
+		Description: "Name of device in `devices` argument of provider" +
+			" to run the action instead of the device of provider.",
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+}
+
+func (act *actionWithDevice) innerSchema(ctx context.Context) (schema.Schema, tftypes.Type) {
+	var resp action.SchemaResponse
+	act.inner.Schema(ctx, action.SchemaRequest{}, &resp)
+
+	return resp.Schema, resp.Schema.Type().TerraformType(ctx)
+}
+
+// configureInner configure the wrapped action with the client of device.
+func (act *actionWithDevice) configureInner(
+	ctx context.Context, device tftypes.Value, diags *diag.Diagnostics,
+) bool {
+	if act.client == nil || !device.IsKnown() {
+		return true
+	}
+	client, err := act.client.DeviceClient(deviceName(device))
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(deviceAttrName),
+			"Device Not Found",
+			err.Error(),
+		)
+
+		return false
+	}
+	if inner, ok := act.inner.(action.ActionWithConfigure); ok {
+		var resp action.ConfigureResponse
+		inner.Configure(ctx, action.ConfigureRequest{ProviderData: client}, &resp)
+		diags.Append(resp.Diagnostics...)
+	}
+
+	return !diags.HasError()
+}
+
+func (act *actionWithDevice) ValidateConfig(
+	ctx context.Context, req action.ValidateConfigRequest, resp *action.ValidateConfigResponse,
+) {
+	inner, ok := act.inner.(action.ActionWithValidateConfig)
+	if !ok {
+		return
+	}
+	innerSchema, innerType := act.innerSchema(ctx)
+	config, _ := splitDeviceValue(req.Config.Raw, innerType, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inner.ValidateConfig(ctx, action.ValidateConfigRequest{
+		Config: tfsdk.Config{Schema: innerSchema, Raw: config},
+	}, resp)
+}
+
+func (act *actionWithDevice) Invoke(
+	ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse,
+) {
+	innerSchema, innerType := act.innerSchema(ctx)
+	config, device := splitDeviceValue(req.Config.Raw, innerType, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !act.configureInner(ctx, device, &resp.Diagnostics) {
+		return
+	}
+
+	act.inner.Invoke(ctx, action.InvokeRequest{
+		Config: tfsdk.Config{Schema: innerSchema, Raw: config},
+	}, resp)
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+	"github.com/jeremmfr/terraform-provider-junos/internal/tfdiag"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type commitAtActionWithDeviceData struct {
+	commitAtActionData
+
+	Device types.String `tfsdk:"device"`
+}
+
+// invokeAction invoke the action with data in config.
+func invokeAction(
+	ctx context.Context, t *testing.T, act action.Action, data any,
+) action.InvokeResponse {
+	t.Helper()
+
+	var schemaResp action.SchemaResponse
+	act.Schema(ctx, action.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := state.Set(ctx, data); diags.HasError() {
+		t.Fatalf("setting config: %v", diags)
+	}
+	invokeResp := action.InvokeResponse{SendProgress: func(action.InvokeProgressEvent) {}}
+	act.Invoke(ctx, action.InvokeRequest{Config: tfsdk.Config(state)}, &invokeResp)
+
+	return invokeResp
+}
+
+func TestActionWithDeviceCommitAtDiscardWithSimulator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	srv, _ := newSimulatorClient(t, "set system host-name router1")
+	client, err := junos.NewClient("192.0.2.1").
+		WithUserName("test").
+		WithPassword("test").
+		WithSleepShort(0).
+		WithSleepLock(0).
+		WithDevices(map[string]junos.Device{
+			"sw1": {IP: srv.IP(), Port: srv.Port()},
+		})
+	if err != nil {
+		t.Fatalf("adding devices: %s", err)
+	}
+	act := actionsWithDevice([]func() action.Action{newCommitAtAction})[0]()
+	act.(action.ActionWithConfigure).Configure(ctx,
+		action.ConfigureRequest{ProviderData: client}, &action.ConfigureResponse{})
+
+	invokeResp := invokeAction(ctx, t, act, &commitAtActionWithDeviceData{
+		commitAtActionData: commitAtActionData{
+			Config: types.StringValue("set system host-name router2"),
+			AtTime: types.StringValue("reboot"),
+			Action: types.StringValue(junos.LoadConfigActionSet),
+		},
+		Device: types.StringValue("sw3"),
+	})
+	if !invokeResp.Diagnostics.HasError() ||
+		invokeResp.Diagnostics.Errors()[0].Summary() != "Device Not Found" {
+		t.Errorf("got unexpected diagnostics with unknown device: %v", invokeResp.Diagnostics)
+	}
+
+	// the commit at-time fails on simulator, the loaded changes are discarded
+	invokeResp = invokeAction(ctx, t, act, &commitAtActionWithDeviceData{
+		commitAtActionData: commitAtActionData{
+			Config: types.StringValue("set system host-name router2"),
+			AtTime: types.StringValue("reboot"),
+			Action: types.StringValue(junos.LoadConfigActionSet),
+		},
+		Device: types.StringValue("sw1"),
+	})
+	if !invokeResp.Diagnostics.HasError() ||
+		invokeResp.Diagnostics.Errors()[0].Summary() != tfdiag.ConfigCommitErrSummary {
+		t.Fatalf("got unexpected diagnostics on commit at: %v", invokeResp.Diagnostics)
+	}
+	discarded := false
+	for _, v := range invokeResp.Diagnostics.Warnings() {
+		if v.Summary() == tfdiag.ConfigDiscardWarnSummary {
+			discarded = true
+		}
+	}
+	if !discarded {
+		t.Errorf("expected a warning with the discarded changes, got %v", invokeResp.Diagnostics)
+	}
+	expectConfig := []string{"set system host-name router1"}
+	if v := srv.Candidate(); !sameLines(v, expectConfig) {
+		t.Errorf("got unexpected candidate after error: %q, want %q", v, expectConfig)
+	}
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"maps"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &dataSourceWithDevice{}
+	_ datasource.DataSourceWithConfigure      = &dataSourceWithDevice{}
+	_ datasource.DataSourceWithValidateConfig = &dataSourceWithDevice{}
+)
+
+// dataSourceWithDevice wrap a data source to add the `device` attribute
+// and use the client of selected device in devices of provider.
+//
+// Like resourceWithDevice, the `device` attribute is hidden to the wrapped data source.
+type dataSourceWithDevice struct {
+	inner  datasource.DataSource
+	client *junos.Client
+}
+
+func dataSourcesWithDevice(dataSources []func() datasource.DataSource) []func() datasource.DataSource {
+	wrapped := make([]func() datasource.DataSource, len(dataSources))
+	for i, newDataSource := range dataSources {
+		wrapped[i] = func() datasource.DataSource {
+			return &dataSourceWithDevice{
+				inner: newDataSource(),
+			}
+		}
+	}
+
+	return wrapped
+}
+
+func (dsc *dataSourceWithDevice) Metadata(
+	ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse,
+) {
+	dsc.inner.Metadata(ctx, req, resp)
+}
+
+func (dsc *dataSourceWithDevice) Configure(
+	ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse,
+) {
+	if client, ok := req.ProviderData.(*junos.Client); ok {
+		dsc.client = client
+	}
+	if inner, ok := dsc.inner.(datasource.DataSourceWithConfigure); ok {
+		inner.Configure(ctx, req, resp)
+	}
+}
+
+func (dsc *dataSourceWithDevice) Schema(
+	ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse,
+) {
+	dsc.inner.Schema(ctx, req, resp)
+	resp.Schema.Attributes = maps.Clone(resp.Schema.Attributes)
+	if resp.Schema.Attributes == nil {
+		resp.Schema.Attributes = make(map[string]schema.Attribute)
+	}
+	resp.Schema.Attributes[deviceAttrName] = schema.StringAttribute{
+		Optional: true,
+		Description: "Name of device in `devices` argument of provider" +
+			" to read data instead of the device of provider.",
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+}
+
+func (dsc *dataSourceWithDevice) innerSchema(ctx context.Context) (schema.Schema, tftypes.Type) {
+	var resp datasource.SchemaResponse
+	dsc.inner.Schema(ctx, datasource.SchemaRequest{}, &resp)
+
+	return resp.Schema, resp.Schema.Type().TerraformType(ctx)
+}
+
+// configureInner configure the wrapped data source with the client of device.
+func (dsc *dataSourceWithDevice) configureInner(
+	ctx context.Context, device tftypes.Value, diags *diag.Diagnostics,
+) bool {
+	if dsc.client == nil || !device.IsKnown() {
+		return true
+	}
+	client, err := dsc.client.DeviceClient(deviceName(device))
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(deviceAttrName),
+			"Device Not Found",
+			err.Error(),
+		)
+
+		return false
+	}
+	if inner, ok := dsc.inner.(datasource.DataSourceWithConfigure); ok {
+		var resp datasource.ConfigureResponse
+		inner.Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &resp)
+		diags.Append(resp.Diagnostics...)
+	}
+
+	return !diags.HasError()
+}
+
+func (dsc *dataSourceWithDevice) ValidateConfig(
+	ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse,
+) {
+	inner, ok := dsc.inner.(datasource.DataSourceWithValidateConfig)
+	if !ok {
+		return
+	}
+	innerSchema, innerType := dsc.innerSchema(ctx)
+	config, _ := splitDeviceValue(req.Config.Raw, innerType, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inner.ValidateConfig(ctx, datasource.ValidateConfigRequest{
+		Config: tfsdk.Config{Schema: innerSchema, Raw: config},
+	}, resp)
+}
+
+func (dsc *dataSourceWithDevice) Read(
+	ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse,
+) {
+	innerSchema, innerType := dsc.innerSchema(ctx)
+	config, device := splitDeviceValue(req.Config.Raw, innerType, &resp.Diagnostics)
+	state, _ := splitDeviceValue(resp.State.Raw, innerType, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !dsc.configureInner(ctx, device, &resp.Diagnostics) {
+		return
+	}
+
+	innerResp := datasource.ReadResponse{
+		State: tfsdk.State{Schema: innerSchema, Raw: state},
+	}
+	dsc.inner.Read(ctx, datasource.ReadRequest{
+		Config:             tfsdk.Config{Schema: innerSchema, Raw: config},
+		ProviderMeta:       req.ProviderMeta,
+		ClientCapabilities: req.ClientCapabilities,
+	}, &innerResp)
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.Deferred = innerResp.Deferred
+	resp.State.Raw = joinDeviceValue(innerResp.State.Raw, resp.State.Schema.Type().TerraformType(ctx), device)
+}
@@ -0,0 +1,554 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"strings"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &resourceWithDevice{}
+	_ resource.ResourceWithConfigure      = &resourceWithDevice{}
+	_ resource.ResourceWithValidateConfig = &resourceWithDevice{}
+	_ resource.ResourceWithModifyPlan     = &resourceWithDevice{}
+	_ resource.ResourceWithImportState    = &resourceWithDevice{}
+	_ resource.ResourceWithUpgradeState   = &resourceWithDevice{}
+)
+
+const (
+	deviceAttrName = "device"
+	// prefix of import ID to import the resource from a device in devices of provider:
+	// device:<name>/<id>.
+	deviceImportIDPrefix = "device:"
+)
+
+// resourceWithDevice wrap a resource to add the `device` attribute
+// and use the client of selected device in devices of provider.
+//
+// The `device` attribute (and the `junos_diff` attribute) is removed from config/plan/state
+// before calling the wrapped resource and added back in responses,
+// so the wrapped resource doesn't need to know it.
+type resourceWithDevice struct {
+	inner    resource.Resource
+	newInner func() resource.Resource
+	client   *junos.Client
+}
+
+func resourcesWithDevice(resources []func() resource.Resource) []func() resource.Resource {
+	wrapped := make([]func() resource.Resource, len(resources))
+	for i, newResource := range resources {
+		// resources with a list resource need an identity
+		if _, ok := configListResourceIDs[resourceTypeName(newResource())]; ok {
+			wrapped[i] = func() resource.Resource {
+				return &resourceWithDeviceIdentity{
+					resourceWithDevice: &resourceWithDevice{
+						inner:    newResource(),
+						newInner: newResource,
+					},
+				}
+			}
+
+			continue
+		}
+		wrapped[i] = func() resource.Resource {
+			return &resourceWithDevice{
+				inner:    newResource(),
+				newInner: newResource,
+			}
+		}
+	}
+
+	return wrapped
+}
+
+func (rsc *resourceWithDevice) Metadata(
+	ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse,
+) {
+	rsc.inner.Metadata(ctx, req, resp)
+}
+
+func (rsc *resourceWithDevice) Configure(
+	ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse,
+) {
+	if client, ok := req.ProviderData.(*junos.Client); ok {
+		rsc.client = client
+	}
+	if inner, ok := rsc.inner.(resource.ResourceWithConfigure); ok {
+		inner.Configure(ctx, req, resp)
+	}
+}
+
+func (rsc *resourceWithDevice) Schema(
+	ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse,
+) {
+	rsc.inner.Schema(ctx, req, resp)
+	resp.Schema.Attributes = schemaAttributesWithDevice(resp.Schema.Attributes)
+	resp.Schema.Attributes[junosDiffAttrName] = junosDiffSchemaAttribute()
+}
+
+func schemaAttributesWithDevice(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	attributes = maps.Clone(attributes)
+	if attributes == nil {
+		attributes = make(map[string]schema.Attribute)
+	}
+	attributes[deviceAttrName] = schema.StringAttribute{
+		Optional: true,
+		Description: "Name of device in `devices` argument of provider" +
+			" to manage the resource instead of the device of provider.",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+
+	return attributes
+}
+
+func (rsc *resourceWithDevice) innerSchema(ctx context.Context) (schema.Schema, tftypes.Type) {
+	var resp resource.SchemaResponse
+	rsc.inner.Schema(ctx, resource.SchemaRequest{}, &resp)
+
+	return resp.Schema, resp.Schema.Type().TerraformType(ctx)
+}
+
+// configureInner configure the wrapped resource with the client of device.
+func (rsc *resourceWithDevice) configureInner(
+	ctx context.Context, device tftypes.Value, diags *diag.Diagnostics,
+) bool {
+	if rsc.client == nil || !device.IsKnown() {
+		return true
+	}
+	client, err := rsc.client.DeviceClient(deviceName(device))
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(deviceAttrName),
+			"Device Not Found",
+			err.Error(),
+		)
+
+		return false
+	}
+	if inner, ok := rsc.inner.(resource.ResourceWithConfigure); ok {
+		var resp resource.ConfigureResponse
+		inner.Configure(ctx, resource.ConfigureRequest{ProviderData: client}, &resp)
+		diags.Append(resp.Diagnostics...)
+	}
+
+	return !diags.HasError()
+}
+
+func (rsc *resourceWithDevice) ValidateConfig(
+	ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse,
+) {
+	inner, ok := rsc.inner.(resource.ResourceWithValidateConfig)
+	if !ok {
+		return
+	}
+	innerSchema, innerType := rsc.innerSchema(ctx)
+	config, _ := splitDeviceValue(req.Config.Raw, innerType, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inner.ValidateConfig(ctx, resource.ValidateConfigRequest{
+		Config:             tfsdk.Config{Schema: innerSchema, Raw: config},
+		ClientCapabilities: req.ClientCapabilities,
+	}, resp)
+}
+
+func (rsc *resourceWithDevice) ModifyPlan(
+	ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse,
+) {
+	defer func() {
+		// junos_diff is unknown in plan when the resource has changes,
+		// it stays unknown if the lines are not generated (to accept the lines of replan in apply)
+		// and it's null without plan_junos_diff
+		if (rsc.client == nil || !rsc.client.PlanJunosDiff()) && !junosDiffValue(resp.Plan.Raw).IsKnown() {
+			resp.Plan.Raw = withJunosDiffValue(resp.Plan.Raw, tftypes.NewValue(tftypes.String, nil))
+		}
+	}()
+	inner, ok := rsc.inner.(resource.ResourceWithModifyPlan)
+	if !ok && !rsc.planLinesNeeded() {
+		return
+	}
+	innerSchema, innerType := rsc.innerSchema(ctx)
+	config, _ := splitDeviceValue(req.Config.Raw, innerType, &resp.Diagnostics)
+	state, stateDevice := splitDeviceValue(req.State.Raw, innerType, &resp.Diagnostics)
+	plan, device := splitDeviceValue(req.Plan.Raw, innerType, &resp.Diagnostics)
+	respPlan, respDevice := splitDeviceValue(resp.Plan.Raw, innerType, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if req.Plan.Raw.IsNull() {
+		// destroy plan
+		device = stateDevice
+	}
+	if !rsc.configureInner(ctx, device, &resp.Diagnostics) {
+		return
+	}
+
+	if ok {
+		innerResp := resource.ModifyPlanResponse{
+			Plan:            tfsdk.Plan{Schema: innerSchema, Raw: respPlan},
+			Identity:        resp.Identity,
+			RequiresReplace: resp.RequiresReplace,
+			Private:         resp.Private,
+		}
+		inner.ModifyPlan(ctx, resource.ModifyPlanRequest{
+			Config:             tfsdk.Config{Schema: innerSchema, Raw: config},
+			State:              tfsdk.State{Schema: innerSchema, Raw: state},
+			Identity:           req.Identity,
+			Plan:               tfsdk.Plan{Schema: innerSchema, Raw: plan},
+			ProviderMeta:       req.ProviderMeta,
+			Private:            req.Private,
+			ClientCapabilities: req.ClientCapabilities,
+		}, &innerResp)
+		resp.Diagnostics.Append(innerResp.Diagnostics...)
+		resp.Identity = innerResp.Identity
+		resp.RequiresReplace = innerResp.RequiresReplace
+		resp.Private = innerResp.Private
+		resp.Deferred = innerResp.Deferred
+		respJunosDiff := junosDiffValue(resp.Plan.Raw)
+		resp.Plan.Raw = joinDeviceValue(innerResp.Plan.Raw, resp.Plan.Schema.Type().TerraformType(ctx), respDevice)
+		resp.Plan.Raw = withJunosDiffValue(resp.Plan.Raw, respJunosDiff)
+		respPlan = innerResp.Plan.Raw
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if rsc.planLinesNeeded() {
+		rsc.planLines(ctx, device, innerSchema, config, state, respPlan, req, resp)
+	}
+}
+
+func (rsc *resourceWithDevice) Create(
+	ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse,
+) {
+	innerSchema, innerType := rsc.innerSchema(ctx)
+	config, _ := splitDeviceValue(req.Config.Raw, innerType, &resp.Diagnostics)
+	plan, device := splitDeviceValue(req.Plan.Raw, innerType, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !rsc.configureInner(ctx, device, &resp.Diagnostics) {
+		return
+	}
+	// the next plans of resources are the replans of apply phase
+	rsc.client.EndPlanPhase()
+
+	innerResp := resource.CreateResponse{
+		State:    tfsdk.State{Schema: innerSchema, Raw: tftypes.NewValue(innerType, nil)},
+		Identity: resp.Identity,
+		Private:  resp.Private,
+	}
+	rsc.inner.Create(ctx, resource.CreateRequest{
+		Config:       tfsdk.Config{Schema: innerSchema, Raw: config},
+		Plan:         tfsdk.Plan{Schema: innerSchema, Raw: plan},
+		Identity:     req.Identity,
+		ProviderMeta: req.ProviderMeta,
+	}, &innerResp)
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.Identity = innerResp.Identity
+	resp.Private = innerResp.Private
+	resp.State.Raw = joinDeviceValue(innerResp.State.Raw, resp.State.Schema.Type().TerraformType(ctx), device)
+	resp.State.Raw = withJunosDiffValue(resp.State.Raw, appliedJunosDiffValue(req.Plan.Raw))
+}
+
+func (rsc *resourceWithDevice) Read(
+	ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse,
+) {
+	innerSchema, innerType := rsc.innerSchema(ctx)
+	state, device := splitDeviceValue(req.State.Raw, innerType, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !rsc.configureInner(ctx, device, &resp.Diagnostics) {
+		return
+	}
+
+	innerResp := resource.ReadResponse{
+		State:    tfsdk.State{Schema: innerSchema, Raw: state},
+		Identity: resp.Identity,
+		Private:  resp.Private,
+	}
+	rsc.inner.Read(ctx, resource.ReadRequest{
+		State:              tfsdk.State{Schema: innerSchema, Raw: state},
+		Identity:           req.Identity,
+		Private:            req.Private,
+		ProviderMeta:       req.ProviderMeta,
+		ClientCapabilities: req.ClientCapabilities,
+	}, &innerResp)
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.Identity = innerResp.Identity
+	resp.Private = innerResp.Private
+	resp.Deferred = innerResp.Deferred
+	resp.State.Raw = joinDeviceValue(innerResp.State.Raw, resp.State.Schema.Type().TerraformType(ctx), device)
+	resp.State.Raw = withJunosDiffValue(resp.State.Raw, junosDiffValue(req.State.Raw))
+}
+
+func (rsc *resourceWithDevice) Update(
+	ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse,
+) {
+	innerSchema, innerType := rsc.innerSchema(ctx)
+	config, _ := splitDeviceValue(req.Config.Raw, innerType, &resp.Diagnostics)
+	plan, device := splitDeviceValue(req.Plan.Raw, innerType, &resp.Diagnostics)
+	state, _ := splitDeviceValue(req.State.Raw, innerType, &resp.Diagnostics)
+	respState, _ := splitDeviceValue(resp.State.Raw, innerType, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !rsc.configureInner(ctx, device, &resp.Diagnostics) {
+		return
+	}
+	// the next plans of resources are the replans of apply phase
+	rsc.client.EndPlanPhase()
+
+	innerResp := resource.UpdateResponse{
+		State:    tfsdk.State{Schema: innerSchema, Raw: respState},
+		Identity: resp.Identity,
+		Private:  resp.Private,
+	}
+	rsc.inner.Update(ctx, resource.UpdateRequest{
+		Config:       tfsdk.Config{Schema: innerSchema, Raw: config},
+		Plan:         tfsdk.Plan{Schema: innerSchema, Raw: plan},
+		State:        tfsdk.State{Schema: innerSchema, Raw: state},
+		Identity:     req.Identity,
+		ProviderMeta: req.ProviderMeta,
+		Private:      req.Private,
+	}, &innerResp)
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.Identity = innerResp.Identity
+	resp.Private = innerResp.Private
+	resp.State.Raw = joinDeviceValue(innerResp.State.Raw, resp.State.Schema.Type().TerraformType(ctx), device)
+	resp.State.Raw = withJunosDiffValue(resp.State.Raw, appliedJunosDiffValue(req.Plan.Raw))
+}
+
+func (rsc *resourceWithDevice) Delete(
+	ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse,
+) {
+	innerSchema, innerType := rsc.innerSchema(ctx)
+	state, device := splitDeviceValue(req.State.Raw, innerType, &resp.Diagnostics)
+	respState, _ := splitDeviceValue(resp.State.Raw, innerType, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !rsc.configureInner(ctx, device, &resp.Diagnostics) {
+		return
+	}
+	// the next plans of resources are the replans of apply phase
+	rsc.client.EndPlanPhase()
+
+	innerResp := resource.DeleteResponse{
+		State:    tfsdk.State{Schema: innerSchema, Raw: respState},
+		Identity: resp.Identity,
+		Private:  resp.Private,
+	}
+	rsc.inner.Delete(ctx, resource.DeleteRequest{
+		State:        tfsdk.State{Schema: innerSchema, Raw: state},
+		Identity:     req.Identity,
+		ProviderMeta: req.ProviderMeta,
+		Private:      req.Private,
+	}, &innerResp)
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.Identity = innerResp.Identity
+	resp.Private = innerResp.Private
+	resp.State.Raw = joinDeviceValue(innerResp.State.Raw, resp.State.Schema.Type().TerraformType(ctx), device)
+}
+
+func (rsc *resourceWithDevice) ImportState(
+	ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse,
+) {
+	inner, ok := rsc.inner.(resource.ResourceWithImportState)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Resource Import Not Implemented",
+			"This resource does not support import. "+
+				"Please contact the provider developer for additional information.",
+		)
+
+		return
+	}
+	innerSchema, innerType := rsc.innerSchema(ctx)
+	id := req.ID
+	device := tftypes.NewValue(tftypes.String, nil)
+	if v, ok := strings.CutPrefix(req.ID, deviceImportIDPrefix); ok {
+		name, innerID, ok := strings.Cut(v, "/")
+		if !ok || name == "" {
+			resp.Diagnostics.AddError(
+				"Bad ID Format",
+				"missing '/' to separate device name and resource ID in import ID with format "+
+					deviceImportIDPrefix+"<device>/<id>",
+			)
+
+			return
+		}
+		id = innerID
+		device = tftypes.NewValue(tftypes.String, name)
+	}
+	if !rsc.configureInner(ctx, device, &resp.Diagnostics) {
+		return
+	}
+
+	innerResp := resource.ImportStateResponse{
+		State:    tfsdk.State{Schema: innerSchema, Raw: tftypes.NewValue(innerType, nil)},
+		Identity: resp.Identity,
+		Private:  resp.Private,
+	}
+	inner.ImportState(ctx, resource.ImportStateRequest{
+		ID:                 id,
+		Identity:           req.Identity,
+		ClientCapabilities: req.ClientCapabilities,
+	}, &innerResp)
+	resp.Diagnostics.Append(innerResp.Diagnostics...)
+	resp.Identity = innerResp.Identity
+	resp.Private = innerResp.Private
+	resp.Deferred = innerResp.Deferred
+	resp.State.Raw = joinDeviceValue(innerResp.State.Raw, resp.State.Schema.Type().TerraformType(ctx), device)
+}
+
+func (rsc *resourceWithDevice) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	inner, ok := rsc.inner.(resource.ResourceWithUpgradeState)
+	if !ok {
+		return nil
+	}
+	innerSchema, innerType := rsc.innerSchema(ctx)
+	var resp resource.SchemaResponse
+	rsc.Schema(ctx, resource.SchemaRequest{}, &resp)
+	outerType := resp.Schema.Type().TerraformType(ctx)
+
+	upgraders := inner.UpgradeState(ctx)
+	wrapped := make(map[int64]resource.StateUpgrader, len(upgraders))
+	for version, upgrader := range upgraders {
+		var priorSchema *schema.Schema
+		var innerPriorType tftypes.Type
+		if upgrader.PriorSchema != nil {
+			innerPriorType = upgrader.PriorSchema.Type().TerraformType(ctx)
+			priorSchemaWithDevice := *upgrader.PriorSchema
+			priorSchemaWithDevice.Attributes = schemaAttributesWithDevice(upgrader.PriorSchema.Attributes)
+			priorSchema = &priorSchemaWithDevice
+		}
+		wrapped[version] = resource.StateUpgrader{
+			PriorSchema: priorSchema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				innerReq := resource.UpgradeStateRequest{
+					RawState: req.RawState,
+				}
+				device := tftypes.NewValue(tftypes.String, nil)
+				if req.State != nil && upgrader.PriorSchema != nil {
+					var state tftypes.Value
+					state, device = splitDeviceValue(req.State.Raw, innerPriorType, &resp.Diagnostics)
+					if resp.Diagnostics.HasError() {
+						return
+					}
+					innerReq.State = &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: state}
+				}
+
+				innerResp := resource.UpgradeStateResponse{
+					State: tfsdk.State{Schema: innerSchema, Raw: tftypes.NewValue(innerType, nil)},
+				}
+				upgrader.StateUpgrader(ctx, innerReq, &innerResp)
+				resp.Diagnostics.Append(innerResp.Diagnostics...)
+				if innerResp.DynamicValue != nil {
+					resp.DynamicValue = innerResp.DynamicValue
+
+					return
+				}
+				resp.State.Raw = joinDeviceValue(innerResp.State.Raw, outerType, device)
+			},
+		}
+	}
+
+	return wrapped
+}
+
+// deviceName return the name of device in the value of `device` attribute (empty if null).
+func deviceName(device tftypes.Value) string {
+	var name string
+	if device.IsKnown() && !device.IsNull() {
+		_ = device.As(&name)
+	}
+
+	return name
+}
+
+// splitDeviceValue split the value of object with the `device` attribute
+// to the value of object with the type without `device` attribute and the value of `device` attribute.
+func splitDeviceValue(
+	raw tftypes.Value, innerType tftypes.Type, diags *diag.Diagnostics,
+) (
+	tftypes.Value, tftypes.Value,
+) {
+	switch {
+	case raw.Type() == nil || raw.IsNull():
+		return tftypes.NewValue(innerType, nil), tftypes.NewValue(tftypes.String, nil)
+	case !raw.IsKnown():
+		return tftypes.NewValue(innerType, tftypes.UnknownValue), tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := raw.As(&attributes); err != nil {
+		diags.AddError(
+			"Unexpected Object Value",
+			"Unable to read attributes of object to extract '"+deviceAttrName+"' attribute: "+err.Error()+
+				"\nPlease report this issue to the provider developers.",
+		)
+
+		return tftypes.NewValue(innerType, nil), tftypes.NewValue(tftypes.String, nil)
+	}
+	// don't modify the map shared with raw
+	attributes = maps.Clone(attributes)
+	device, ok := attributes[deviceAttrName]
+	if !ok {
+		device = tftypes.NewValue(tftypes.String, nil)
+	}
+	delete(attributes, deviceAttrName)
+	delete(attributes, junosDiffAttrName)
+
+	return tftypes.NewValue(innerType, attributes), device
+}
+
+// joinDeviceValue add the value of `device` attribute in the value of object
+// to have a value of object with the type with `device` attribute.
+//
+// The other attributes of type missing in the value of object (like `junos_diff`) are null.
+func joinDeviceValue(raw tftypes.Value, outerType tftypes.Type, device tftypes.Value) tftypes.Value {
+	switch {
+	case raw.Type() == nil || raw.IsNull():
+		return tftypes.NewValue(outerType, nil)
+	case !raw.IsKnown():
+		return tftypes.NewValue(outerType, tftypes.UnknownValue)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := raw.As(&attributes); err != nil {
+		return tftypes.NewValue(outerType, nil)
+	}
+	attributes = maps.Clone(attributes)
+	attributes[deviceAttrName] = device
+	if objectType, ok := outerType.(tftypes.Object); ok {
+		for k, v := range objectType.AttributeTypes {
+			if _, ok := attributes[k]; !ok {
+				attributes[k] = tftypes.NewValue(v, nil)
+			}
+		}
+	}
+
+	return tftypes.NewValue(outerType, attributes)
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &resourceWithDeviceIdentity{}
+	_ resource.ResourceWithIdentity    = &resourceWithDeviceIdentity{}
+	_ resource.ResourceWithImportState = &resourceWithDeviceIdentity{}
+)
+
+// resourceWithDeviceIdentity add a resource identity (`id` and `device`)
+// to a resource wrapped by resourceWithDevice.
+//
+// The identity is necessary to list the resource with a list resource.
+type resourceWithDeviceIdentity struct {
+	*resourceWithDevice
+}
+
+type resourceIdentityWithDevice struct {
+	ID     types.String `tfsdk:"id"`
+	Device types.String `tfsdk:"device"`
+}
+
+func (rsc *resourceWithDeviceIdentity) IdentitySchema(
+	_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "ID of the resource (the `id` attribute).",
+			},
+			deviceAttrName: identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "Name of device in `devices` argument of provider (the `device` attribute).",
+			},
+		},
+	}
+}
+
+func (rsc *resourceWithDeviceIdentity) Create(
+	ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse,
+) {
+	rsc.resourceWithDevice.Create(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	setIdentityWithDevice(ctx, resp.Identity, resp.State.Raw, &resp.Diagnostics)
+}
+
+func (rsc *resourceWithDeviceIdentity) Read(
+	ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse,
+) {
+	rsc.resourceWithDevice.Read(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if resp.State.Raw.IsNull() {
+		// resource not found, keep the identity of prior state
+		setIdentityWithDevice(ctx, resp.Identity, req.State.Raw, &resp.Diagnostics)
+
+		return
+	}
+	setIdentityWithDevice(ctx, resp.Identity, resp.State.Raw, &resp.Diagnostics)
+}
+
+func (rsc *resourceWithDeviceIdentity) Update(
+	ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse,
+) {
+	rsc.resourceWithDevice.Update(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	setIdentityWithDevice(ctx, resp.Identity, resp.State.Raw, &resp.Diagnostics)
+}
+
+func (rsc *resourceWithDeviceIdentity) ImportState(
+	ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse,
+) {
+	if req.ID == "" && req.Identity != nil {
+		// import with identity instead of ID
+		var identity resourceIdentityWithDevice
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		req.ID = identity.ID.ValueString()
+		if v := identity.Device.ValueString(); v != "" {
+			req.ID = deviceImportIDPrefix + v + "/" + req.ID
+		}
+	}
+	rsc.resourceWithDevice.ImportState(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	setIdentityWithDevice(ctx, resp.Identity, resp.State.Raw, &resp.Diagnostics)
+}
+
+// setIdentityWithDevice set the identity with the `id` and `device` attributes of state.
+func setIdentityWithDevice(
+	ctx context.Context, identity *tfsdk.ResourceIdentity, state tftypes.Value, diags *diag.Diagnostics,
+) {
+	if identity == nil || state.Type() == nil || state.IsNull() || !state.IsKnown() {
+		return
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := state.As(&attributes); err != nil {
+		diags.AddError(
+			"Unexpected Object Value",
+			"Unable to read attributes of object to set resource identity: "+err.Error()+
+				"\nPlease report this issue to the provider developers.",
+		)
+
+		return
+	}
+	var id string
+	if v, ok := attributes["id"]; ok && v.IsKnown() && !v.IsNull() {
+		_ = v.As(&id)
+	}
+	if id == "" {
+		return
+	}
+
+	data := resourceIdentityWithDevice{
+		ID:     types.StringValue(id),
+		Device: types.StringNull(),
+	}
+	if v := deviceName(attributes[deviceAttrName]); v != "" {
+		data.Device = types.StringValue(v)
+	}
+	diags.Append(identity.Set(ctx, data)...)
+}
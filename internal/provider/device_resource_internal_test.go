@@ -0,0 +1,511 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+	"github.com/jeremmfr/terraform-provider-junos/internal/tfdiag"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type deviceTestResource struct {
+	client *junos.Client
+}
+
+type deviceTestResourceData struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func (rsc *deviceTestResource) Metadata(
+	_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse,
+) {
+	resp.TypeName = providerName + "_test"
+}
+
+func (rsc *deviceTestResource) Configure(
+	_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse,
+) {
+	if client, ok := req.ProviderData.(*junos.Client); ok {
+		rsc.client = client
+	}
+}
+
+func (rsc *deviceTestResource) Schema(
+	_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+		},
+	}
+}
+
+func (rsc *deviceTestResource) Create(
+	ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse,
+) {
+	var plan deviceTestResourceData
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if rsc.client.FakeCreateSetFile() {
+		junSess := rsc.client.NewSessionWithoutNetconf(ctx)
+		if err := junSess.ConfigSet(ctx, []string{"set test " + plan.Name.ValueString()}); err != nil {
+			resp.Diagnostics.AddError("ConfigSet", err.Error())
+
+			return
+		}
+	}
+	plan.ID = plan.Name
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (rsc *deviceTestResource) Read(
+	_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse,
+) {
+}
+
+func (rsc *deviceTestResource) Update(
+	_ context.Context, _ resource.UpdateRequest, _ *resource.UpdateResponse,
+) {
+}
+
+func (rsc *deviceTestResource) Delete(
+	_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse,
+) {
+}
+
+func (rsc *deviceTestResource) ImportState(
+	ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse,
+) {
+	resp.Diagnostics.Append(resp.State.Set(ctx, &deviceTestResourceData{
+		ID:   types.StringValue(req.ID),
+		Name: types.StringValue(req.ID),
+	})...)
+}
+
+func TestResourceWithDevice(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, err := junos.NewClient("192.0.2.1").WithDevices(map[string]junos.Device{
+		"sw1": {IP: "192.0.2.11"},
+	})
+	if err != nil {
+		t.Fatalf("adding devices: %s", err)
+	}
+	sw1Client, err := client.DeviceClient("sw1")
+	if err != nil {
+		t.Fatalf("getting client of device: %s", err)
+	}
+
+	type testCase struct {
+		device       tftypes.Value
+		importID     string
+		expectClient *junos.Client
+		expectError  bool
+	}
+	tests := map[string]testCase{
+		"without_device": {
+			device:       tftypes.NewValue(tftypes.String, nil),
+			importID:     "name1",
+			expectClient: client,
+		},
+		"with_device": {
+			device:       tftypes.NewValue(tftypes.String, "sw1"),
+			importID:     "device:sw1/name1",
+			expectClient: sw1Client,
+		},
+		"unknown_device": {
+			device:      tftypes.NewValue(tftypes.String, "sw2"),
+			importID:    "device:sw2/name1",
+			expectError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			inner := &deviceTestResource{}
+			rsc := &resourceWithDevice{inner: inner}
+			var schemaResp resource.SchemaResponse
+			rsc.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+			if _, ok := schemaResp.Schema.Attributes[deviceAttrName]; !ok {
+				t.Fatalf("missing %s attribute in schema", deviceAttrName)
+			}
+			rsc.Configure(ctx, resource.ConfigureRequest{ProviderData: client}, &resource.ConfigureResponse{})
+
+			schemaType := schemaResp.Schema.Type().TerraformType(ctx)
+			plan := tftypes.NewValue(schemaType, map[string]tftypes.Value{
+				"id":              tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"name":            tftypes.NewValue(tftypes.String, "name1"),
+				deviceAttrName:    test.device,
+				junosDiffAttrName: tftypes.NewValue(tftypes.String, nil),
+			})
+			createResp := resource.CreateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, nil)},
+			}
+			rsc.Create(ctx, resource.CreateRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: plan},
+				Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+			}, &createResp)
+
+			importResp := resource.ImportStateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, nil)},
+			}
+			rsc.ImportState(ctx, resource.ImportStateRequest{ID: test.importID}, &importResp)
+
+			if test.expectError {
+				if !createResp.Diagnostics.HasError() {
+					t.Errorf("expected error on create but got none")
+				}
+				if !importResp.Diagnostics.HasError() {
+					t.Errorf("expected error on import but got none")
+				}
+
+				return
+			}
+			if createResp.Diagnostics.HasError() {
+				t.Fatalf("got unexpected error on create: %v", createResp.Diagnostics)
+			}
+			if importResp.Diagnostics.HasError() {
+				t.Fatalf("got unexpected error on import: %v", importResp.Diagnostics)
+			}
+			if inner.client != test.expectClient {
+				t.Errorf("inner resource not configured with the expected client")
+			}
+			for _, state := range []tfsdk.State{createResp.State, importResp.State} {
+				var id, device types.String
+				state.GetAttribute(ctx, path.Root("id"), &id)
+				state.GetAttribute(ctx, path.Root(deviceAttrName), &device)
+				if id.ValueString() != "name1" {
+					t.Errorf("got unexpected id %q in state", id.ValueString())
+				}
+				expectDevice := types.StringNull()
+				if !test.device.IsNull() {
+					expectDevice = types.StringValue(deviceName(test.device))
+				}
+				if !device.Equal(expectDevice) {
+					t.Errorf("got unexpected device %s in state", device)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceWithDeviceJunosDiff(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := junos.NewClient("192.0.2.1").WithPlanJunosDiff()
+
+	rsc := &resourceWithDevice{
+		inner:    &deviceTestResource{},
+		newInner: func() resource.Resource { return &deviceTestResource{} },
+	}
+	var schemaResp resource.SchemaResponse
+	rsc.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	rsc.Configure(ctx, resource.ConfigureRequest{ProviderData: client}, &resource.ConfigureResponse{})
+
+	schemaType := schemaResp.Schema.Type().TerraformType(ctx)
+	config := tftypes.NewValue(schemaType, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, nil),
+		"name":            tftypes.NewValue(tftypes.String, "name1"),
+		deviceAttrName:    tftypes.NewValue(tftypes.String, nil),
+		junosDiffAttrName: tftypes.NewValue(tftypes.String, nil),
+	})
+	plan := tftypes.NewValue(schemaType, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"name":            tftypes.NewValue(tftypes.String, "name1"),
+		deviceAttrName:    tftypes.NewValue(tftypes.String, nil),
+		junosDiffAttrName: tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	modifyPlanResp := resource.ModifyPlanResponse{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+	}
+	rsc.ModifyPlan(ctx, resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, nil)},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+	}, &modifyPlanResp)
+	if modifyPlanResp.Diagnostics.HasError() {
+		t.Fatalf("got unexpected error on plan: %v", modifyPlanResp.Diagnostics)
+	}
+	var junosDiff types.String
+	modifyPlanResp.Plan.GetAttribute(ctx, path.Root(junosDiffAttrName), &junosDiff)
+	if junosDiff.ValueString() != "set test name1" {
+		t.Fatalf("got unexpected %s %s in plan", junosDiffAttrName, junosDiff)
+	}
+
+	createResp := resource.CreateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, nil)},
+	}
+	rsc.Create(ctx, resource.CreateRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+		Plan:   modifyPlanResp.Plan,
+	}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("got unexpected error on create: %v", createResp.Diagnostics)
+	}
+	var stateJunosDiff types.String
+	createResp.State.GetAttribute(ctx, path.Root(junosDiffAttrName), &stateJunosDiff)
+	if !stateJunosDiff.Equal(junosDiff) {
+		t.Errorf("got %s %s in state, expected the planned value", junosDiffAttrName, stateJunosDiff)
+	}
+}
+
+func TestResourceWithDeviceJunosDiffNotGenerated(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tests := map[string]struct {
+		client     *junos.Client
+		expectNull bool
+	}{
+		"plan_junos_diff": {
+			client: junos.NewClient("192.0.2.1").WithPlanJunosDiff(),
+		},
+		"plan_commit_check": {
+			client:     junos.NewClient("192.0.2.1").WithPlanCommitCheck(),
+			expectNull: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			rsc := &resourceWithDevice{
+				inner:    &deviceTestResource{},
+				newInner: func() resource.Resource { return &deviceTestResource{} },
+			}
+			var schemaResp resource.SchemaResponse
+			rsc.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+			rsc.Configure(ctx, resource.ConfigureRequest{ProviderData: test.client}, &resource.ConfigureResponse{})
+
+			// name unknown until apply, the lines can't be generated
+			schemaType := schemaResp.Schema.Type().TerraformType(ctx)
+			config := tftypes.NewValue(schemaType, map[string]tftypes.Value{
+				"id":              tftypes.NewValue(tftypes.String, nil),
+				"name":            tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				deviceAttrName:    tftypes.NewValue(tftypes.String, nil),
+				junosDiffAttrName: tftypes.NewValue(tftypes.String, nil),
+			})
+			plan := tftypes.NewValue(schemaType, map[string]tftypes.Value{
+				"id":              tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"name":            tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				deviceAttrName:    tftypes.NewValue(tftypes.String, nil),
+				junosDiffAttrName: tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			})
+			modifyPlanResp := resource.ModifyPlanResponse{
+				Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+			}
+			rsc.ModifyPlan(ctx, resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+				State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, nil)},
+				Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+			}, &modifyPlanResp)
+			if modifyPlanResp.Diagnostics.HasError() {
+				t.Fatalf("got unexpected error on plan: %v", modifyPlanResp.Diagnostics)
+			}
+			var junosDiff types.String
+			modifyPlanResp.Plan.GetAttribute(ctx, path.Root(junosDiffAttrName), &junosDiff)
+			if test.expectNull && !junosDiff.IsNull() {
+				t.Errorf("got %s %s in plan, expected null", junosDiffAttrName, junosDiff)
+			}
+			if !test.expectNull && !junosDiff.IsUnknown() {
+				t.Errorf("got %s %s in plan, expected unknown", junosDiffAttrName, junosDiff)
+			}
+		})
+	}
+}
+
+// deviceTestSensitiveResource: test resource with a sensitive attribute in its set lines.
+type deviceTestSensitiveResource struct {
+	deviceTestResource
+}
+
+type deviceTestSensitiveResourceData struct {
+	ID     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Secret types.String `tfsdk:"secret"`
+}
+
+func (rsc *deviceTestSensitiveResource) Schema(
+	ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse,
+) {
+	rsc.deviceTestResource.Schema(ctx, req, resp)
+	resp.Schema.Attributes["secret"] = schema.StringAttribute{
+		Required:  true,
+		Sensitive: true,
+	}
+}
+
+func (rsc *deviceTestSensitiveResource) Create(
+	ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse,
+) {
+	var plan deviceTestSensitiveResourceData
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	junSess := rsc.client.NewSessionWithoutNetconf(ctx)
+	if err := junSess.ConfigSet(ctx, []string{
+		"set test " + plan.Name.ValueString() + " community \"" + plan.Secret.ValueString() + "\" read-only",
+		"set test " + plan.Name.ValueString() + " key " + plan.Secret.ValueString(),
+	}); err != nil {
+		resp.Diagnostics.AddError("ConfigSet", err.Error())
+
+		return
+	}
+	plan.ID = plan.Name
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func TestResourceWithDeviceJunosDiffSensitive(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := junos.NewClient("192.0.2.1").WithPlanJunosDiff()
+
+	rsc := &resourceWithDevice{
+		inner:    &deviceTestSensitiveResource{},
+		newInner: func() resource.Resource { return &deviceTestSensitiveResource{} },
+	}
+	var schemaResp resource.SchemaResponse
+	rsc.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	rsc.Configure(ctx, resource.ConfigureRequest{ProviderData: client}, &resource.ConfigureResponse{})
+
+	schemaType := schemaResp.Schema.Type().TerraformType(ctx)
+	config := tftypes.NewValue(schemaType, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, nil),
+		"name":            tftypes.NewValue(tftypes.String, "name1"),
+		"secret":          tftypes.NewValue(tftypes.String, "s3cr3t"),
+		deviceAttrName:    tftypes.NewValue(tftypes.String, nil),
+		junosDiffAttrName: tftypes.NewValue(tftypes.String, nil),
+	})
+	plan := tftypes.NewValue(schemaType, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"name":            tftypes.NewValue(tftypes.String, "name1"),
+		"secret":          tftypes.NewValue(tftypes.String, "s3cr3t"),
+		deviceAttrName:    tftypes.NewValue(tftypes.String, nil),
+		junosDiffAttrName: tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	modifyPlanResp := resource.ModifyPlanResponse{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+	}
+	rsc.ModifyPlan(ctx, resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, nil)},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+	}, &modifyPlanResp)
+	if modifyPlanResp.Diagnostics.HasError() {
+		t.Fatalf("got unexpected error on plan: %v", modifyPlanResp.Diagnostics)
+	}
+	var junosDiff types.String
+	modifyPlanResp.Plan.GetAttribute(ctx, path.Root(junosDiffAttrName), &junosDiff)
+	expect := "set test name1 community \"redacted\" read-only\n" +
+		"set test name1 key redacted"
+	if junosDiff.ValueString() != expect {
+		t.Errorf("got unexpected %s %q in plan, want %q", junosDiffAttrName, junosDiff.ValueString(), expect)
+	}
+}
+
+// deviceTestCommandResource: test resource which needs a connection to the device to create it.
+type deviceTestCommandResource struct {
+	deviceTestResource
+}
+
+func (rsc *deviceTestCommandResource) Create(
+	ctx context.Context, _ resource.CreateRequest, resp *resource.CreateResponse,
+) {
+	junSess := rsc.client.NewSessionWithoutNetconf(ctx)
+	if _, err := junSess.Command(ctx, junos.CmdShowConfig+"test"+junos.PipeDisplaySetRelative); err != nil {
+		resp.Diagnostics.AddError("Command", err.Error())
+	}
+}
+
+func TestResourceWithDevicePlanLinesWithoutConnection(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := junos.NewClient("192.0.2.1").WithPlanJunosDiff()
+
+	rsc := &resourceWithDevice{
+		inner:    &deviceTestCommandResource{},
+		newInner: func() resource.Resource { return &deviceTestCommandResource{} },
+	}
+	innerSchema, innerType := rsc.innerSchema(ctx)
+	plan := tftypes.NewValue(innerType, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, nil),
+		"name": tftypes.NewValue(tftypes.String, "name1"),
+	})
+	lines, ok := rsc.planResourceLines(ctx, client, innerSchema,
+		plan, tftypes.NewValue(innerType, nil), plan, resource.ModifyPlanRequest{}, false)
+	if ok || len(lines) > 0 {
+		t.Errorf("got lines %q (%t) with resource which needs a connection, expected none", lines, ok)
+	}
+}
+
+func TestResourceWithDevicePlanCommitCheckWithSimulator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	srv, client := newSimulatorClient(t)
+	client = client.WithPlanCommitCheck()
+	// like a reference to an object of a resource planned after
+	srv.SetCommitCheckError("policy-statement not defined")
+
+	rsc := &resourceWithDevice{
+		inner:    &deviceTestResource{},
+		newInner: func() resource.Resource { return &deviceTestResource{} },
+	}
+	var schemaResp resource.SchemaResponse
+	rsc.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	rsc.Configure(ctx, resource.ConfigureRequest{ProviderData: client}, &resource.ConfigureResponse{})
+
+	schemaType := schemaResp.Schema.Type().TerraformType(ctx)
+	config := tftypes.NewValue(schemaType, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, nil),
+		"name":            tftypes.NewValue(tftypes.String, "name1"),
+		deviceAttrName:    tftypes.NewValue(tftypes.String, nil),
+		junosDiffAttrName: tftypes.NewValue(tftypes.String, nil),
+	})
+	plan := tftypes.NewValue(schemaType, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"name":            tftypes.NewValue(tftypes.String, "name1"),
+		deviceAttrName:    tftypes.NewValue(tftypes.String, nil),
+		junosDiffAttrName: tftypes.NewValue(tftypes.String, nil),
+	})
+	modifyPlanResp := resource.ModifyPlanResponse{
+		Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+	}
+	rsc.ModifyPlan(ctx, resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, nil)},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+	}, &modifyPlanResp)
+	if modifyPlanResp.Diagnostics.HasError() {
+		t.Fatalf("got unexpected error on plan: %v", modifyPlanResp.Diagnostics)
+	}
+	warnings := modifyPlanResp.Diagnostics.Warnings()
+	if len(warnings) != 1 ||
+		warnings[0].Summary() != tfdiag.PlanCommitCheckWarnSummary ||
+		!strings.Contains(warnings[0].Detail(), "policy-statement not defined") {
+		t.Errorf("got diagnostics %v, expected a warning of commit check", modifyPlanResp.Diagnostics)
+	}
+}
@@ -0,0 +1,311 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+	"github.com/jeremmfr/terraform-provider-junos/internal/tfdiag"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const junosDiffAttrName = "junos_diff"
+
+func junosDiffSchemaAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Computed: true,
+		Description: "Set/delete lines generated for the device by the last planned action on the resource" +
+			" (only with `plan_junos_diff` argument of provider, with the secrets redacted).",
+	}
+}
+
+// planLinesNeeded return true if the provider needs the set/delete lines of resource actions in plan.
+func (rsc *resourceWithDevice) planLinesNeeded() bool {
+	if rsc.client == nil {
+		return false
+	}
+
+	return rsc.client.PlanCommitCheck() || rsc.client.PlanJunosDiff()
+}
+
+// planLines generate the set/delete lines of the resource action in plan
+// to set the `junos_diff` attribute in plan and check the commit of them.
+func (rsc *resourceWithDevice) planLines(
+	ctx context.Context,
+	device tftypes.Value,
+	innerSchema schema.Schema,
+	config, state, plan tftypes.Value,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	client, err := rsc.client.DeviceClient(deviceName(device))
+	if err != nil {
+		return
+	}
+	lines, ok := rsc.planResourceLines(ctx, client, innerSchema, config, state, plan, req, len(resp.RequiresReplace) > 0)
+	if !ok {
+		return
+	}
+
+	// junos_diff is unknown in plan only when the resource has changes,
+	// otherwise the value in state is kept
+	if client.PlanJunosDiff() && !plan.IsNull() && !junosDiffValue(resp.Plan.Raw).IsKnown() {
+		resp.Plan.Raw = withJunosDiffValue(resp.Plan.Raw, tftypes.NewValue(tftypes.String,
+			junosDiffLines(lines, sensitiveStrings(ctx, innerSchema, config, state, plan))))
+	}
+	if client.PlanCommitCheck() && len(lines) > 0 {
+		warns, err := client.CommitCheckPlanLines(ctx, lines)
+		resp.Diagnostics.Append(tfdiag.Warns(tfdiag.PlanCommitCheckWarnSummary, warns)...)
+		// not an error of plan: the lines can reference an object of a resource planned after
+		if err != nil {
+			resp.Diagnostics.AddWarning(tfdiag.PlanCommitCheckWarnSummary, err.Error())
+		}
+	}
+}
+
+// junosDiffLines return the lines to set in `junos_diff` attribute with the secrets redacted
+// (the values of sensitive attributes and the values after a keyword of secret).
+func junosDiffLines(lines, secrets []string) string {
+	// the longest first to not redact only a part of a secret
+	slices.SortFunc(secrets, func(a, b string) int {
+		return cmp.Compare(len(b), len(a))
+	})
+	diffLines := make([]string, len(lines))
+	for i, line := range lines {
+		for _, secret := range secrets {
+			line = strings.ReplaceAll(line, `"`+secret+`"`, `"`+junos.RedactedSecret+`"`)
+			line = strings.ReplaceAll(line, " "+secret+" ", " "+junos.RedactedSecret+" ")
+			if v, ok := strings.CutSuffix(line, " "+secret); ok {
+				line = v + " " + junos.RedactedSecret
+			}
+		}
+		diffLines[i] = junos.RedactSecrets(line)
+	}
+
+	return strings.Join(diffLines, "\n")
+}
+
+// sensitiveStrings return the non-empty strings in values of sensitive (or write-only) attributes.
+func sensitiveStrings(ctx context.Context, innerSchema schema.Schema, values ...tftypes.Value) []string {
+	secrets := make([]string, 0)
+	collect := func(_ *tftypes.AttributePath, v tftypes.Value) (bool, error) {
+		var str string
+		if v.Type().Is(tftypes.String) && v.IsKnown() && !v.IsNull() && v.As(&str) == nil && str != "" {
+			secrets = append(secrets, str)
+		}
+
+		return true, nil
+	}
+	for _, value := range values {
+		_ = tftypes.Walk(value, func(p *tftypes.AttributePath, v tftypes.Value) (bool, error) {
+			if len(p.Steps()) == 0 {
+				return true, nil
+			}
+			if _, ok := p.LastStep().(tftypes.AttributeName); !ok {
+				return true, nil
+			}
+			attr, err := innerSchema.AttributeAtTerraformPath(ctx, p)
+			if err != nil || (!attr.IsSensitive() && !attr.IsWriteOnly()) {
+				return true, nil
+			}
+
+			return false, tftypes.Walk(v, collect)
+		})
+	}
+
+	return secrets
+}
+
+// planResourceLines generate the set/delete lines that the resource action in plan
+// (create, update, delete or replace) would send to the device.
+//
+// The lines are generated by a new instance of wrapped resource configured with a client
+// which captures them (with the same process as the fake options of provider).
+// The sessions of this client have no connection to the device,
+// the functions which need it return an error (and the resource action an error diagnostic).
+// Return false if lines can't be generated (unknown values in config,
+// wrapped resource which needs a connection to the device to generate them, ...).
+func (rsc *resourceWithDevice) planResourceLines(
+	ctx context.Context,
+	client *junos.Client,
+	innerSchema schema.Schema,
+	config, state, plan tftypes.Value,
+	req resource.ModifyPlanRequest,
+	replace bool,
+) (
+	lines []string, ok bool,
+) {
+	switch {
+	case rsc.newInner == nil:
+		return nil, false
+	case plan.IsNull() && state.IsNull():
+		return nil, false
+	case !plan.IsNull() && (!config.IsFullyKnown() || !plan.IsKnown()):
+		return nil, false
+	case !plan.IsNull() && !state.IsNull() && plan.Equal(state):
+		return nil, true
+	}
+
+	inner := rsc.newInner()
+	if innerConfigure, ok := inner.(resource.ResourceWithConfigure); ok {
+		innerConfigure.Configure(ctx, resource.ConfigureRequest{
+			ProviderData: client.NewLinesCaptureClient(func(v []string) error {
+				lines = append(lines, v...)
+
+				return nil
+			}),
+		}, &resource.ConfigureResponse{})
+	}
+	// computed values are unknown in plan but can't be used to generate lines
+	plan, err := tftypes.Transform(plan, func(_ *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if !v.IsKnown() {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+
+		return v, nil
+	})
+	if err != nil {
+		return nil, false
+	}
+
+	var diags diag.Diagnostics
+	if replace && !plan.IsNull() && !state.IsNull() {
+		// delete then create
+		deleteResp := resource.DeleteResponse{
+			State: tfsdk.State{Schema: innerSchema, Raw: state},
+		}
+		inner.Delete(ctx, resource.DeleteRequest{
+			State:   tfsdk.State{Schema: innerSchema, Raw: state},
+			Private: req.Private,
+		}, &deleteResp)
+		if deleteResp.Diagnostics.HasError() {
+			return nil, false
+		}
+		state = tftypes.NewValue(state.Type(), nil)
+	}
+	switch {
+	case plan.IsNull():
+		deleteResp := resource.DeleteResponse{
+			State: tfsdk.State{Schema: innerSchema, Raw: state},
+		}
+		inner.Delete(ctx, resource.DeleteRequest{
+			State:   tfsdk.State{Schema: innerSchema, Raw: state},
+			Private: req.Private,
+		}, &deleteResp)
+		diags = deleteResp.Diagnostics
+	case state.IsNull():
+		createResp := resource.CreateResponse{
+			State: tfsdk.State{Schema: innerSchema, Raw: tftypes.NewValue(plan.Type(), nil)},
+		}
+		inner.Create(ctx, resource.CreateRequest{
+			Config: tfsdk.Config{Schema: innerSchema, Raw: config},
+			Plan:   tfsdk.Plan{Schema: innerSchema, Raw: plan},
+		}, &createResp)
+		diags = createResp.Diagnostics
+	default:
+		updateResp := resource.UpdateResponse{
+			State: tfsdk.State{Schema: innerSchema, Raw: state},
+		}
+		inner.Update(ctx, resource.UpdateRequest{
+			Config:  tfsdk.Config{Schema: innerSchema, Raw: config},
+			Plan:    tfsdk.Plan{Schema: innerSchema, Raw: plan},
+			State:   tfsdk.State{Schema: innerSchema, Raw: state},
+			Private: req.Private,
+		}, &updateResp)
+		diags = updateResp.Diagnostics
+	}
+	if diags.HasError() {
+		return nil, false
+	}
+
+	return lines, true
+}
+
+// importState import the resource with its ID from the device of client
+// and return the state of wrapped resource.
+func (rsc *resourceWithDevice) importState(
+	ctx context.Context, client *junos.Client, id string,
+) (
+	schema.Schema, tftypes.Value, diag.Diagnostics,
+) {
+	var diags diag.Diagnostics
+	innerSchema, innerType := rsc.innerSchema(ctx)
+	inner, ok := rsc.newInner().(resource.ResourceWithImportState)
+	if !ok {
+		diags.AddError("Resource Import Not Implemented", "This resource does not support import.")
+
+		return innerSchema, tftypes.NewValue(innerType, nil), diags
+	}
+	if innerConfigure, ok := inner.(resource.ResourceWithConfigure); ok {
+		var configureResp resource.ConfigureResponse
+		innerConfigure.Configure(ctx, resource.ConfigureRequest{ProviderData: client}, &configureResp)
+		diags.Append(configureResp.Diagnostics...)
+		if diags.HasError() {
+			return innerSchema, tftypes.NewValue(innerType, nil), diags
+		}
+	}
+	importResp := resource.ImportStateResponse{
+		State: tfsdk.State{Schema: innerSchema, Raw: tftypes.NewValue(innerType, nil)},
+	}
+	inner.ImportState(ctx, resource.ImportStateRequest{ID: id}, &importResp)
+	diags.Append(importResp.Diagnostics...)
+
+	return innerSchema, importResp.State.Raw, diags
+}
+
+// stateLines return the set/delete lines that the resource would generate
+// to create it with the state of wrapped resource.
+func (rsc *resourceWithDevice) stateLines(
+	ctx context.Context, client *junos.Client, innerSchema schema.Schema, state tftypes.Value,
+) (
+	[]string, bool,
+) {
+	return rsc.planResourceLines(ctx, client, innerSchema,
+		state, tftypes.NewValue(state.Type(), nil), state, resource.ModifyPlanRequest{}, false)
+}
+
+// junosDiffValue return the value of `junos_diff` attribute in the value of object (null if missing).
+func junosDiffValue(raw tftypes.Value) tftypes.Value {
+	var attributes map[string]tftypes.Value
+	if raw.Type() == nil || !raw.IsKnown() || raw.IsNull() || raw.As(&attributes) != nil {
+		return tftypes.NewValue(tftypes.String, nil)
+	}
+	if v, ok := attributes[junosDiffAttrName]; ok {
+		return v
+	}
+
+	return tftypes.NewValue(tftypes.String, nil)
+}
+
+// appliedJunosDiffValue return the value of `junos_diff` attribute in plan to set in state after apply
+// (null if unknown in plan).
+func appliedJunosDiffValue(plan tftypes.Value) tftypes.Value {
+	if v := junosDiffValue(plan); v.IsKnown() {
+		return v
+	}
+
+	return tftypes.NewValue(tftypes.String, nil)
+}
+
+// withJunosDiffValue replace the value of `junos_diff` attribute in the value of object.
+func withJunosDiffValue(raw, junosDiff tftypes.Value) tftypes.Value {
+	var attributes map[string]tftypes.Value
+	if raw.Type() == nil || !raw.IsKnown() || raw.IsNull() || raw.As(&attributes) != nil {
+		return raw
+	}
+	if _, ok := attributes[junosDiffAttrName]; !ok {
+		return raw
+	}
+	attributes = maps.Clone(attributes)
+	attributes[junosDiffAttrName] = junosDiff
+
+	return tftypes.NewValue(raw.Type(), attributes)
+}
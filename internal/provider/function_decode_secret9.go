@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junossecret"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &decodeSecret9Function{}
+
+type decodeSecret9Function struct{}
+
+func newDecodeSecret9Function() function.Function {
+	return &decodeSecret9Function{}
+}
+
+func (fct *decodeSecret9Function) Metadata(
+	_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse,
+) {
+	resp.Name = "decode_secret9"
+}
+
+func (fct *decodeSecret9Function) Definition(
+	_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary:     "Decode a Junos encrypted secret ($9$).",
+		Description: "Decode a Junos encrypted secret (`$9$`) without connection to the device.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "encoded",
+				Description: "Encrypted secret to decode (with the `$9$` prefix).",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (fct *decodeSecret9Function) Run(
+	ctx context.Context, req function.RunRequest, resp *function.RunResponse,
+) {
+	var encoded string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &encoded))
+	if resp.Error != nil {
+		return
+	}
+
+	secret, err := junossecret.Decode9(encoded)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, secret))
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junossecret"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &encodeSecret9Function{}
+
+type encodeSecret9Function struct{}
+
+func newEncodeSecret9Function() function.Function {
+	return &encodeSecret9Function{}
+}
+
+func (fct *encodeSecret9Function) Metadata(
+	_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse,
+) {
+	resp.Name = "encode_secret9"
+}
+
+func (fct *encodeSecret9Function) Definition(
+	_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Encode a secret like the Junos encrypted secret ($9$).",
+		Description: "Encode a secret in ASCII characters like the Junos encrypted secret (`$9$`)" +
+			" without connection to the device." +
+			" The result is derived from the secret so that the same secret always gives the same result," +
+			" but a device can encode the same secret with a different result.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "secret",
+				Description: "Secret to encode.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (fct *encodeSecret9Function) Run(
+	ctx context.Context, req function.RunRequest, resp *function.RunResponse,
+) {
+	var secret string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &secret))
+	if resp.Error != nil {
+		return
+	}
+
+	encoded, err := junossecret.Encode9(secret)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, encoded))
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junossecret"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &hashPasswordSHA512Function{}
+
+type hashPasswordSHA512Function struct{}
+
+func newHashPasswordSHA512Function() function.Function {
+	return &hashPasswordSHA512Function{}
+}
+
+func (fct *hashPasswordSHA512Function) Metadata(
+	_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse,
+) {
+	resp.Name = "hash_password_sha512"
+}
+
+func (fct *hashPasswordSHA512Function) Definition(
+	_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Hash a password with SHA-512 crypt ($6$).",
+		Description: "Hash a password with SHA-512 crypt (`$6$`) and a salt" +
+			" like the `encrypted-password` of Junos login users, without connection to the device." +
+			" Use the salt of an existing hash to compare a password with it.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "password",
+				Description: "Password to hash.",
+			},
+			function.StringParameter{
+				Name: "salt",
+				Description: "Salt of hash (1 to 16 characters in `./0-9A-Za-z`)" +
+					" to have the same hash for the same password.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (fct *hashPasswordSHA512Function) Run(
+	ctx context.Context, req function.RunRequest, resp *function.RunResponse,
+) {
+	var password, salt string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &password, &salt))
+	if resp.Error != nil {
+		return
+	}
+
+	hash, err := junossecret.HashSHA512(password, salt)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(1, err.Error()))
+
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, hash))
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSecretFunctions(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		function     function.Function
+		arguments    []attr.Value
+		expectResult string
+		expectError  bool
+	}
+
+	tests := map[string]testCase{
+		"DecodeSecret9": {
+			function:     newDecodeSecret9Function(),
+			arguments:    []attr.Value{types.StringValue("$9$1HFIyKXxdsgJ-VH.Pfn6lKMXdsZUi5Qnikfz")},
+			expectResult: "testPassWord",
+		},
+		"DecodeSecret9Invalid": {
+			function:    newDecodeSecret9Function(),
+			arguments:   []attr.Value{types.StringValue("testPassWord")},
+			expectError: true,
+		},
+		"EncodeSecret9Empty": {
+			function:    newEncodeSecret9Function(),
+			arguments:   []attr.Value{types.StringValue("")},
+			expectError: true,
+		},
+		"HashPasswordSHA512": {
+			function:  newHashPasswordSHA512Function(),
+			arguments: []attr.Value{types.StringValue("Hello world!"), types.StringValue("saltstring")},
+			expectResult: "$6$saltstring$" +
+				"svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1",
+		},
+		"HashPasswordSHA512InvalidSalt": {
+			function:    newHashPasswordSHA512Function(),
+			arguments:   []attr.Value{types.StringValue("Hello world!"), types.StringValue("salt$string")},
+			expectError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := runFunction(test.function, test.arguments...)
+			if test.expectError {
+				if err == nil {
+					t.Errorf("got no error, want error")
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("got unexpected error: %s", err)
+			}
+			if result != test.expectResult {
+				t.Errorf("got unexpected result %q, want %q", result, test.expectResult)
+			}
+		})
+	}
+}
+
+func TestSecretFunctionsEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := runFunction(newEncodeSecret9Function(), types.StringValue("testPassWord"))
+	if err != nil {
+		t.Fatalf("got unexpected error on encode: %s", err)
+	}
+	decoded, err := runFunction(newDecodeSecret9Function(), types.StringValue(encoded))
+	if err != nil {
+		t.Fatalf("got unexpected error on decode: %s", err)
+	}
+	if decoded != "testPassWord" {
+		t.Errorf("got unexpected decoded secret %q from %q", decoded, encoded)
+	}
+}
+
+func runFunction(fct function.Function, arguments ...attr.Value) (string, error) {
+	resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	fct.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(arguments)}, &resp)
+	if resp.Error != nil {
+		return "", resp.Error
+	}
+
+	return resp.Result.Value().(types.String).ValueString(), nil
+}
@@ -0,0 +1,383 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+	"github.com/jeremmfr/terraform-provider-junos/internal/tfdiag"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &configListResource{}
+	_ list.ListResourceWithConfigure = &configListResource{}
+)
+
+// configListWord: a word in a set line, with quotes if it contains spaces or special characters.
+const configListWord = `("[^"]*"|[^ ]+)`
+
+// configListResourceIDs: for each type of resource with a list resource,
+// the function to extract the ID of resource from a set line of configuration.
+var configListResourceIDs = map[string]func(line string) (string, bool){
+	providerName + "_application": configListIDFromRegexp(
+		`^set applications application `+configListWord+`(?: |$)`,
+		func(m []string) string { return m[1] },
+	),
+	providerName + "_application_set": configListIDFromRegexp(
+		`^set applications application-set `+configListWord+`(?: |$)`,
+		func(m []string) string { return m[1] },
+	),
+	providerName + "_bgp_group": configListIDFromRegexp(
+		`^set (?:routing-instances `+configListWord+` )?protocols bgp group `+configListWord+`(?: |$)`,
+		func(m []string) string { return m[2] + junos.IDSeparator + configListRoutingInstance(m[1]) },
+	),
+	providerName + "_bgp_neighbor": configListIDFromRegexp(
+		`^set (?:routing-instances `+configListWord+` )?protocols bgp group `+configListWord+
+			` neighbor `+configListWord+`(?: |$)`,
+		func(m []string) string {
+			return m[3] + junos.IDSeparator + configListRoutingInstance(m[1]) + junos.IDSeparator + m[2]
+		},
+	),
+	providerName + "_interface_logical": func(line string) (string, bool) {
+		m := configListInterfaceLogicalRegexp.FindStringSubmatch(line)
+		if m == nil || !configListPhysicalInterface(m[1]) {
+			return "", false
+		}
+
+		return m[1] + "." + m[2], true
+	},
+	providerName + "_interface_physical": func(line string) (string, bool) {
+		// interfaces with at least one line which is not for a logical interface
+		// (or for the group to consider the interface available)
+		m := configListInterfacePhysicalRegexp.FindStringSubmatch(line)
+		if m == nil || !configListPhysicalInterface(m[1]) {
+			return "", false
+		}
+		switch m[2] {
+		case "unit", "apply-groups", "apply-groups-except":
+			return "", false
+		}
+
+		return m[1], true
+	},
+	providerName + "_policyoptions_as_path": configListIDFromRegexp(
+		`^set policy-options as-path `+configListWord+`(?: |$)`,
+		func(m []string) string { return m[1] },
+	),
+	providerName + "_policyoptions_as_path_group": configListIDFromRegexp(
+		`^set policy-options as-path-group `+configListWord+`(?: |$)`,
+		func(m []string) string { return m[1] },
+	),
+	providerName + "_policyoptions_community": configListIDFromRegexp(
+		`^set policy-options community `+configListWord+`(?: |$)`,
+		func(m []string) string { return m[1] },
+	),
+	providerName + "_policyoptions_policy_statement": configListIDFromRegexp(
+		`^set policy-options policy-statement `+configListWord+`(?: |$)`,
+		func(m []string) string { return m[1] },
+	),
+	providerName + "_policyoptions_prefix_list": configListIDFromRegexp(
+		`^set policy-options prefix-list `+configListWord+`(?: |$)`,
+		func(m []string) string { return m[1] },
+	),
+	providerName + "_routing_instance": configListIDFromRegexp(
+		`^set routing-instances `+configListWord+`(?: |$)`,
+		func(m []string) string { return m[1] },
+	),
+	providerName + "_security_policy": configListIDFromRegexp(
+		`^set security policies from-zone `+configListWord+` to-zone `+configListWord+`(?: |$)`,
+		func(m []string) string { return m[1] + junos.IDSeparator + m[2] },
+	),
+	providerName + "_security_zone": configListIDFromRegexp(
+		`^set security zones security-zone `+configListWord+`(?: |$)`,
+		func(m []string) string { return m[1] },
+	),
+	providerName + "_static_route": configListIDFromRegexp(
+		`^set (?:routing-instances `+configListWord+` )?routing-options (?:rib `+configListWord+` )?`+
+			`static route `+configListWord+`(?: |$)`,
+		func(m []string) string { return m[3] + junos.IDSeparator + configListRoutingInstance(m[1]) },
+	),
+	providerName + "_vlan": configListIDFromRegexp(
+		`^set (?:routing-instances `+configListWord+` )?vlans `+configListWord+`(?: |$)`,
+		func(m []string) string { return m[2] + junos.IDSeparator + configListRoutingInstance(m[1]) },
+	),
+}
+
+var (
+	configListInterfaceLogicalRegexp = regexp.MustCompile(
+		`^set interfaces ` + configListWord + ` unit ` + configListWord + `(?: |$)`)
+	configListInterfacePhysicalRegexp = regexp.MustCompile(
+		`^set interfaces ` + configListWord + ` ` + configListWord)
+)
+
+// configListIDFromRegexp return a function to extract the ID of resource
+// from a set line with a regular expression (the quotes around submatches are removed).
+func configListIDFromRegexp(expr string, id func(m []string) string) func(line string) (string, bool) {
+	re := regexp.MustCompile(expr)
+
+	return func(line string) (string, bool) {
+		m := re.FindStringSubmatch(line)
+		if m == nil {
+			return "", false
+		}
+		for i := range m {
+			m[i] = strings.Trim(m[i], `"`)
+		}
+
+		return id(m), true
+	}
+}
+
+// configListRoutingInstance return the routing instance in ID of resource (default if empty).
+func configListRoutingInstance(name string) string {
+	if name == "" {
+		return junos.DefaultW
+	}
+
+	return name
+}
+
+// configListPhysicalInterface return if the name after `interfaces` is a physical interface
+// and not an other statement under `interfaces`.
+func configListPhysicalInterface(name string) bool {
+	switch {
+	case strings.HasPrefix(name, `"`),
+		strings.HasPrefix(name, "<"),
+		strings.Contains(name, "."):
+		return false
+	case name == "apply-groups",
+		name == "apply-groups-except",
+		name == "interface-range",
+		name == "interface-set",
+		name == "traceoptions":
+		return false
+	}
+
+	return true
+}
+
+// configListIDs return the IDs (without duplicate) of resources found in the set lines of configuration.
+func configListIDs(typeName string, lines []string) []string {
+	idFromLine, ok := configListResourceIDs[typeName]
+	if !ok {
+		return nil
+	}
+	ids := make([]string, 0)
+	found := make(map[string]struct{})
+	for _, line := range lines {
+		id, ok := idFromLine(strings.TrimSpace(line))
+		if !ok {
+			continue
+		}
+		if _, ok := found[id]; ok {
+			continue
+		}
+		found[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// configListResource: a list resource to find the resources of a type
+// in the configuration of device (with `show configuration | display set`).
+type configListResource struct {
+	typeName    string
+	newResource func() resource.Resource
+	client      *junos.Client
+}
+
+type configListResourceConfig struct {
+	Device types.String `tfsdk:"device"`
+}
+
+// configListResources return the list resources for the resources with
+// an extraction of ID in configListResourceIDs.
+func configListResources(resources []func() resource.Resource) []func() list.ListResource {
+	listResources := make([]func() list.ListResource, 0, len(configListResourceIDs))
+	for _, newResource := range resources {
+		typeName := resourceTypeName(newResource())
+		if _, ok := configListResourceIDs[typeName]; !ok {
+			continue
+		}
+		listResources = append(listResources, func() list.ListResource {
+			return &configListResource{
+				typeName:    typeName,
+				newResource: newResource,
+			}
+		})
+	}
+
+	return listResources
+}
+
+// resourceTypeName return the type name of resource.
+func resourceTypeName(rsc resource.Resource) string {
+	var resp resource.MetadataResponse
+	rsc.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: providerName}, &resp)
+
+	return resp.TypeName
+}
+
+func (lrsc *configListResource) Metadata(
+	_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse,
+) {
+	resp.TypeName = lrsc.typeName
+}
+
+func (lrsc *configListResource) Configure(
+	ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*junos.Client)
+	if !ok {
+		unexpectedResourceConfigureType(ctx, req, resp)
+
+		return
+	}
+	lrsc.client = client
+}
+
+func (lrsc *configListResource) ListResourceConfigSchema(
+	_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "List the `" + lrsc.typeName + "` resources found in the configuration of the Junos device.",
+		Attributes: map[string]schema.Attribute{
+			deviceAttrName: schema.StringAttribute{
+				Optional: true,
+				Description: "Name of device in `devices` argument of provider" +
+					" to list the resources instead of the device of provider.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (lrsc *configListResource) List(
+	ctx context.Context, req list.ListRequest, stream *list.ListResultsStream,
+) {
+	var config configListResourceConfig
+	var diags diag.Diagnostics
+	diags.Append(req.Config.Get(ctx, &config)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+	client := lrsc.client
+	if client == nil {
+		diags.AddError(
+			"Unconfigured Provider",
+			"The provider has not been configured to list the resources in the configuration of device.",
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+	if v := config.Device.ValueString(); v != "" {
+		deviceClient, err := client.DeviceClient(v)
+		if err != nil {
+			diags.AddAttributeError(path.Root(deviceAttrName), "Device Not Found", err.Error())
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+			return
+		}
+		client = deviceClient
+	}
+
+	ids, err := lrsc.listIDs(ctx, client)
+	if err != nil {
+		diags.AddError(tfdiag.ConfigReadErrSummary, err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+	if req.Limit > 0 && int64(len(ids)) > req.Limit {
+		ids = ids[:req.Limit]
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, id := range ids {
+			result := req.NewListResult(ctx)
+			result.DisplayName = id
+			result.Diagnostics.Append(result.Identity.Set(ctx, resourceIdentityWithDevice{
+				ID:     types.StringValue(id),
+				Device: config.Device,
+			})...)
+			if req.IncludeResource && !result.Diagnostics.HasError() {
+				lrsc.importResource(ctx, id, config.Device.ValueString(), &result)
+			}
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// listIDs return the IDs of resources found in the configuration of device.
+func (lrsc *configListResource) listIDs(ctx context.Context, client *junos.Client) ([]string, error) {
+	junSess, err := client.StartNewSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer junSess.Close()
+
+	showConfig, err := junSess.Command(ctx, strings.TrimSpace(junos.CmdShowConfig)+junos.PipeDisplaySet)
+	if err != nil {
+		return nil, err
+	}
+
+	return configListIDs(lrsc.typeName, strings.Split(showConfig, "\n")), nil
+}
+
+// importResource fill the resource of result like an import of resource with its ID.
+func (lrsc *configListResource) importResource(
+	ctx context.Context, id, device string, result *list.ListResult,
+) {
+	rsc, ok := lrsc.newResource().(resource.ResourceWithImportState)
+	if !ok {
+		return
+	}
+	if rscWithConfigure, ok := rsc.(resource.ResourceWithConfigure); ok {
+		var configureResp resource.ConfigureResponse
+		rscWithConfigure.Configure(ctx, resource.ConfigureRequest{ProviderData: lrsc.client}, &configureResp)
+		result.Diagnostics.Append(configureResp.Diagnostics...)
+		if result.Diagnostics.HasError() {
+			return
+		}
+	}
+	importID := id
+	if device != "" {
+		importID = deviceImportIDPrefix + device + "/" + id
+	}
+
+	importResp := resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: result.Resource.Schema,
+			Raw:    tftypes.NewValue(result.Resource.Schema.Type().TerraformType(ctx), nil),
+		},
+		Identity: result.Identity,
+	}
+	rsc.ImportState(ctx, resource.ImportStateRequest{ID: importID}, &importResp)
+	result.Diagnostics.Append(importResp.Diagnostics...)
+	result.Resource.Raw = importResp.State.Raw
+}
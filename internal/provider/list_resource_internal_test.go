@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestConfigListIDs(t *testing.T) {
+	t.Parallel()
+
+	lines := []string{
+		"set interfaces ge-0/0/3 description uplink",
+		"set interfaces ge-0/0/3 unit 0 family inet address 192.0.2.1/24",
+		"set interfaces ge-0/0/3 unit 0 description lan",
+		"set interfaces ge-0/0/4 unit 10 vlan-id 10",
+		"set interfaces ge-0/0/5 apply-groups interface-NC",
+		"set interfaces interface-range range1 member ge-0/0/5",
+		"set policy-options policy-statement \"export bgp\" term 1 then accept",
+		"set policy-options prefix-list pl1 192.0.2.0/24",
+		"set policy-options prefix-list pl1 198.51.100.0/24",
+		"set policy-options as-path-group grp1 as-path p1 \"^65000$\"",
+		"set routing-instances ri1 instance-type virtual-router",
+		"set routing-instances ri1 routing-options static route 0.0.0.0/0 next-hop 192.0.2.254",
+		"set routing-instances ri1 protocols bgp group g1 neighbor 192.0.2.2 peer-as 65001",
+		"set routing-instances ri1 vlans v20 vlan-id 20",
+		"set routing-options static route 192.0.2.0/25 discard",
+		"set routing-options rib inet6.0 static route 2001:db8::/32 discard",
+		"set protocols bgp group g2 type external",
+		"set security policies from-zone trust to-zone untrust policy p1 match application any",
+		"set vlans v10 vlan-id 10",
+		"deactivate vlans v10",
+	}
+	tests := map[string][]string{
+		"junos_bgp_group":                      {"g1" + junos.IDSeparator + "ri1", "g2" + junos.IDSeparator + "default"},
+		"junos_bgp_neighbor":                   {"192.0.2.2" + junos.IDSeparator + "ri1" + junos.IDSeparator + "g1"},
+		"junos_interface_logical":              {"ge-0/0/3.0", "ge-0/0/4.10"},
+		"junos_interface_physical":             {"ge-0/0/3"},
+		"junos_policyoptions_as_path":          {},
+		"junos_policyoptions_as_path_group":    {"grp1"},
+		"junos_policyoptions_policy_statement": {"export bgp"},
+		"junos_policyoptions_prefix_list":      {"pl1"},
+		"junos_routing_instance":               {"ri1"},
+		"junos_security_policy":                {"trust" + junos.IDSeparator + "untrust"},
+		"junos_static_route": {
+			"0.0.0.0/0" + junos.IDSeparator + "ri1",
+			"192.0.2.0/25" + junos.IDSeparator + "default",
+			"2001:db8::/32" + junos.IDSeparator + "default",
+		},
+		"junos_vlan": {"v20" + junos.IDSeparator + "ri1", "v10" + junos.IDSeparator + "default"},
+	}
+	for typeName, expect := range tests {
+		if v := configListIDs(typeName, lines); !slices.Equal(v, expect) {
+			t.Errorf("got unexpected IDs for %s: %q, want %q", typeName, v, expect)
+		}
+	}
+}
+
+func TestConfigListResourcesSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := providerserver.NewProtocol6(New())()
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+	if len(schemaResp.Diagnostics) > 0 {
+		t.Fatalf("got unexpected diagnostics: %v", schemaResp.Diagnostics)
+	}
+	identityResp, err := server.GetResourceIdentitySchemas(ctx, &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatalf("got unexpected error: %s", err)
+	}
+	if len(identityResp.Diagnostics) > 0 {
+		t.Fatalf("got unexpected diagnostics: %v", identityResp.Diagnostics)
+	}
+	for typeName := range configListResourceIDs {
+		if _, ok := schemaResp.ListResourceSchemas[typeName]; !ok {
+			t.Errorf("missing list resource %s", typeName)
+		}
+		if _, ok := identityResp.IdentitySchemas[typeName]; !ok {
+			t.Errorf("missing identity schema of resource %s", typeName)
+		}
+	}
+}
+
+func TestConfigListResourceWithSimulator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, client := newSimulatorClient(t,
+		"set applications application app1 protocol tcp",
+		"set applications application app1 destination-port 22",
+		"set applications application app2 protocol udp",
+		"set applications application-set set1 application app1",
+	)
+
+	var newResource func() resource.Resource
+	for _, v := range resourcesWithDevice([]func() resource.Resource{newApplicationResource}) {
+		newResource = v
+	}
+	listResources := configListResources([]func() resource.Resource{newResource})
+	if len(listResources) != 1 {
+		t.Fatalf("got unexpected number of list resources: %d", len(listResources))
+	}
+	lrsc := listResources[0]()
+	lrsc.(list.ListResourceWithConfigure).Configure(ctx,
+		resource.ConfigureRequest{ProviderData: client}, &resource.ConfigureResponse{})
+
+	rsc := newResource()
+	var schemaResp resource.SchemaResponse
+	rsc.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	var identitySchemaResp resource.IdentitySchemaResponse
+	rsc.(resource.ResourceWithIdentity).IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchemaResp)
+	var configSchemaResp list.ListResourceSchemaResponse
+	lrsc.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, &configSchemaResp)
+
+	var stream list.ListResultsStream
+	lrsc.List(ctx, list.ListRequest{
+		Config: tfsdk.Config{
+			Schema: configSchemaResp.Schema,
+			Raw: tftypes.NewValue(configSchemaResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+				deviceAttrName: tftypes.NewValue(tftypes.String, nil),
+			}),
+		},
+		IncludeResource:        true,
+		Limit:                  10,
+		ResourceSchema:         schemaResp.Schema,
+		ResourceIdentitySchema: identitySchemaResp.IdentitySchema,
+	}, &stream)
+
+	var ids []string
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("got unexpected error: %v", result.Diagnostics)
+		}
+		var identity resourceIdentityWithDevice
+		result.Identity.Get(ctx, &identity)
+		if identity.ID.ValueString() != result.DisplayName || !identity.Device.IsNull() {
+			t.Errorf("got unexpected identity for %s: %+v", result.DisplayName, identity)
+		}
+		var data applicationData
+		result.Resource.GetAttribute(ctx, path.Root("id"), &data.ID)
+		if data.ID.ValueString() != result.DisplayName {
+			t.Errorf("got unexpected resource for %s: ID %s", result.DisplayName, data.ID)
+		}
+		ids = append(ids, result.DisplayName)
+	}
+	if expect := []string{"app1", "app2"}; !slices.Equal(ids, expect) {
+		t.Errorf("got unexpected list: %q, want %q", ids, expect)
+	}
+
+	// import with identity
+	importResp := resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+		Identity: &tfsdk.ResourceIdentity{
+			Schema: identitySchemaResp.IdentitySchema,
+			Raw:    tftypes.NewValue(identitySchemaResp.IdentitySchema.Type().TerraformType(ctx), nil),
+		},
+	}
+	importIdentity := tfsdk.ResourceIdentity{
+		Schema: identitySchemaResp.IdentitySchema,
+		Raw:    tftypes.NewValue(identitySchemaResp.IdentitySchema.Type().TerraformType(ctx), nil),
+	}
+	importIdentity.Set(ctx, resourceIdentityWithDevice{
+		ID:     types.StringValue("app2"),
+		Device: types.StringNull(),
+	})
+	rsc.(resource.ResourceWithConfigure).Configure(ctx,
+		resource.ConfigureRequest{ProviderData: client}, &resource.ConfigureResponse{})
+	rsc.(resource.ResourceWithImportState).ImportState(ctx,
+		resource.ImportStateRequest{Identity: &importIdentity}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("got unexpected error on import: %v", importResp.Diagnostics)
+	}
+	var protocol types.String
+	importResp.State.GetAttribute(ctx, path.Root("protocol"), &protocol)
+	if protocol.ValueString() != "udp" {
+		t.Errorf("got unexpected protocol after import with identity: %s", protocol)
+	}
+	if importResp.Identity.Raw.IsFullyNull() {
+		t.Errorf("got unexpected null identity after import")
+	}
+}
@@ -4,17 +4,25 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
+	"slices"
 	"strconv"
+	"time"
 
 	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
 	"github.com/jeremmfr/terraform-provider-junos/internal/tfdiag"
 	"github.com/jeremmfr/terraform-provider-junos/internal/utils"
 	"github.com/jeremmfr/terraform-provider-junos/internal/version"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -29,36 +37,100 @@ func New() provider.Provider {
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider            = &junosProvider{}
-	_ provider.ProviderWithActions = &junosProvider{}
+	_ provider.Provider                  = &junosProvider{}
+	_ provider.ProviderWithActions       = &junosProvider{}
+	_ provider.ProviderWithFunctions     = &junosProvider{}
+	_ provider.ProviderWithListResources = &junosProvider{}
 )
 
 type junosProvider struct{}
 
 type junosProviderModel struct {
-	IP                         types.String `tfsdk:"ip"`
-	Port                       types.Int64  `tfsdk:"port"`
-	Username                   types.String `tfsdk:"username"`
-	Password                   types.String `tfsdk:"password"`
-	SSHKeyPem                  types.String `tfsdk:"sshkey_pem"`
-	SSHKeyFile                 types.String `tfsdk:"sshkeyfile"`
-	SSHKeyPass                 types.String `tfsdk:"keypass"`
-	GroupIntDel                types.String `tfsdk:"group_interface_delete"`
-	NoDecodeSecrets            types.Bool   `tfsdk:"no_decode_secrets"`
-	CmdSleepShort              types.Int64  `tfsdk:"cmd_sleep_short"`
-	CmdSleepLock               types.Int64  `tfsdk:"cmd_sleep_lock"`
-	CommitConfirmed            types.Int64  `tfsdk:"commit_confirmed"`
-	CommitConfirmedWaitPercent types.Int64  `tfsdk:"commit_confirmed_wait_percent"`
-	SleepSSHClosed             types.Int64  `tfsdk:"ssh_sleep_closed"`
-	SSHCiphers                 types.List   `tfsdk:"ssh_ciphers"`
-	SSHTimeoutToEstab          types.Int64  `tfsdk:"ssh_timeout_to_establish"`
-	SSHRetryToEstab            types.Int64  `tfsdk:"ssh_retry_to_establish"`
-	FilePermission             types.String `tfsdk:"file_permission"`
-	DebugNetconfLogPath        types.String `tfsdk:"debug_netconf_log_path"`
-	FakeCreateSetFile          types.String `tfsdk:"fake_create_with_setfile"`
-	FakeUpdateAlso             types.Bool   `tfsdk:"fake_update_also"`
-	FakeDeleteAlso             types.Bool   `tfsdk:"fake_delete_also"`
-	SingleSession              types.Bool   `tfsdk:"single_session"`
+	IP                         types.String                    `tfsdk:"ip"`
+	Port                       types.Int64                     `tfsdk:"port"`
+	Username                   types.String                    `tfsdk:"username"`
+	Password                   types.String                    `tfsdk:"password"`
+	OTP                        types.String                    `tfsdk:"otp"`
+	OTPFile                    types.String                    `tfsdk:"otp_file"`
+	SSHKeyPem                  types.String                    `tfsdk:"sshkey_pem"`
+	SSHKeyFile                 types.String                    `tfsdk:"sshkeyfile"`
+	SSHCertPem                 types.String                    `tfsdk:"sshcert_pem"`
+	SSHCertFile                types.String                    `tfsdk:"sshcertfile"`
+	SSHKeyPass                 types.String                    `tfsdk:"keypass"`
+	GroupIntDel                types.String                    `tfsdk:"group_interface_delete"`
+	NoDecodeSecrets            types.Bool                      `tfsdk:"no_decode_secrets"`
+	CmdSleepShort              types.Int64                     `tfsdk:"cmd_sleep_short"`
+	CmdSleepLock               types.Int64                     `tfsdk:"cmd_sleep_lock"`
+	CommitConfirmed            types.Int64                     `tfsdk:"commit_confirmed"`
+	CommitConfirmedWaitPercent types.Int64                     `tfsdk:"commit_confirmed_wait_percent"`
+	CommitConfirmedDeferred    types.Bool                      `tfsdk:"commit_confirmed_deferred"`
+	CommitConfirmedProbe       []junosProviderBlockProbe       `tfsdk:"commit_confirmed_health_probe"`
+	CommitSynchronize          types.String                    `tfsdk:"commit_synchronize"`
+	ConfigMode                 types.String                    `tfsdk:"config_mode"`
+	PlanCommitCheck            types.Bool                      `tfsdk:"plan_commit_check"`
+	PlanJunosDiff              types.Bool                      `tfsdk:"plan_junos_diff"`
+	SleepSSHClosed             types.Int64                     `tfsdk:"ssh_sleep_closed"`
+	SSHCiphers                 types.List                      `tfsdk:"ssh_ciphers"`
+	SSHTimeoutToEstab          types.Int64                     `tfsdk:"ssh_timeout_to_establish"`
+	SSHRetryToEstab            types.Int64                     `tfsdk:"ssh_retry_to_establish"`
+	SSHHostKeys                types.List                      `tfsdk:"ssh_host_keys"`
+	SSHHostKeyFingerprints     types.List                      `tfsdk:"ssh_host_key_fingerprints"`
+	SSHKnownHostsFile          types.String                    `tfsdk:"ssh_known_hosts_file"`
+	SSHTrustOnFirstUse         types.Bool                      `tfsdk:"ssh_trust_on_first_use"`
+	SSHJumpHost                []junosProviderBlockSSHJumpHost `tfsdk:"ssh_jump_host"`
+	Proxy                      types.String                    `tfsdk:"proxy"`
+	Devices                    map[string]junosProviderDevice  `tfsdk:"devices"`
+	FilePermission             types.String                    `tfsdk:"file_permission"`
+	DebugNetconfLogPath        types.String                    `tfsdk:"debug_netconf_log_path"`
+	FakeCreateSetFile          types.String                    `tfsdk:"fake_create_with_setfile"`
+	FakeUpdateAlso             types.Bool                      `tfsdk:"fake_update_also"`
+	FakeDeleteAlso             types.Bool                      `tfsdk:"fake_delete_also"`
+	SingleSession              types.Bool                      `tfsdk:"single_session"`
+	SessionPoolSize            types.Int64                     `tfsdk:"session_pool_size"`
+	SessionPoolIdleTimeout     types.Int64                     `tfsdk:"session_pool_idle_timeout"`
+	BatchCommit                types.Bool                      `tfsdk:"batch_commit"`
+	BatchCommitWindow          types.Int64                     `tfsdk:"batch_commit_window"`
+}
+
+type junosProviderBlockSSHJumpHost struct {
+	IP          types.String `tfsdk:"ip"`
+	Port        types.Int64  `tfsdk:"port"`
+	Username    types.String `tfsdk:"username"`
+	Password    types.String `tfsdk:"password"`
+	SSHKeyPem   types.String `tfsdk:"sshkey_pem"`
+	SSHKeyFile  types.String `tfsdk:"sshkeyfile"`
+	SSHKeyPass  types.String `tfsdk:"keypass"`
+	SSHCertPem  types.String `tfsdk:"sshcert_pem"`
+	SSHCertFile types.String `tfsdk:"sshcertfile"`
+
+	SSHHostKeys            types.List   `tfsdk:"ssh_host_keys"`
+	SSHHostKeyFingerprints types.List   `tfsdk:"ssh_host_key_fingerprints"`
+	SSHKnownHostsFile      types.String `tfsdk:"ssh_known_hosts_file"`
+	SSHTrustOnFirstUse     types.Bool   `tfsdk:"ssh_trust_on_first_use"`
+}
+
+type junosProviderBlockProbe struct {
+	Name          types.String `tfsdk:"name"`
+	RPC           types.String `tfsdk:"rpc"`
+	XPath         types.String `tfsdk:"xpath"`
+	ExpectedValue types.String `tfsdk:"expected_value"`
+	Attempts      types.Int64  `tfsdk:"attempts"`
+	Interval      types.Int64  `tfsdk:"interval"`
+}
+
+type junosProviderDevice struct {
+	IP          types.String `tfsdk:"ip"`
+	Port        types.Int64  `tfsdk:"port"`
+	Username    types.String `tfsdk:"username"`
+	Password    types.String `tfsdk:"password"`
+	SSHKeyPem   types.String `tfsdk:"sshkey_pem"`
+	SSHKeyFile  types.String `tfsdk:"sshkeyfile"`
+	SSHKeyPass  types.String `tfsdk:"keypass"`
+	SSHCertPem  types.String `tfsdk:"sshcert_pem"`
+	SSHCertFile types.String `tfsdk:"sshcertfile"`
+
+	SSHHostKeys            types.List `tfsdk:"ssh_host_keys"`
+	SSHHostKeyFingerprints types.List `tfsdk:"ssh_host_key_fingerprints"`
 }
 
 const (
@@ -101,6 +173,17 @@ func (p *junosProvider) Schema(
 				Description: "This is a password for ssh connection." +
 					" May also be provided via " + junos.EnvPassword + " environment variable.",
 			},
+			"otp": schema.StringAttribute{
+				Optional: true,
+				Description: "This is a one-time password to answer keyboard-interactive prompts which request it." +
+					" May also be provided via " + junos.EnvOTP + " environment variable.",
+			},
+			"otp_file": schema.StringAttribute{
+				Optional: true,
+				Description: "This is the path to a file to read the one-time password" +
+					" to answer keyboard-interactive prompts which request it." +
+					" May also be provided via " + junos.EnvOTPFile + " environment variable.",
+			},
 			"sshkey_pem": schema.StringAttribute{
 				Optional: true,
 				Description: "This is the ssh key in PEM format for establish ssh connection." +
@@ -111,6 +194,18 @@ func (p *junosProvider) Schema(
 				Description: "This is the path to ssh key for establish ssh connection." +
 					" May also be provided via " + junos.EnvKeyFile + " environment variable.",
 			},
+			"sshcert_pem": schema.StringAttribute{
+				Optional: true,
+				Description: "This is the OpenSSH user certificate (content of `*-cert.pub` file)" +
+					" signed for the ssh key to establish ssh connection." +
+					" May also be provided via " + junos.EnvCertPem + " environment variable.",
+			},
+			"sshcertfile": schema.StringAttribute{
+				Optional: true,
+				Description: "This is the path to OpenSSH user certificate (`*-cert.pub` file)" +
+					" signed for the ssh key to establish ssh connection." +
+					" May also be provided via " + junos.EnvCertFile + " environment variable.",
+			},
 			"keypass": schema.StringAttribute{
 				Optional: true,
 				Description: "This is the passphrase for open `sshkeyfile` or `sshkey_pem`." +
@@ -163,6 +258,54 @@ func (p *junosProvider) Schema(
 					int64validator.Between(0, 99),
 				},
 			},
+			"commit_confirmed_deferred": schema.BoolAttribute{
+				Optional: true,
+				Description: "With `<commit_confirmed>`, don't wait and confirm after each commit" +
+					" but confirm once at the end of the last resource action in progress" +
+					" (which may happen between two waves of resources in the middle of the apply)" +
+					" or with the `junos_commit_confirm` action." +
+					" May also be enabled via " + junos.EnvCommitConfirmedDeferred + " environment variable.",
+			},
+			"commit_synchronize": schema.StringAttribute{
+				Optional: true,
+				Description: "Commit with `synchronize` option to commit on all Routing Engines" +
+					" (or all nodes of chassis cluster):" +
+					" `auto` (only when a chassis cluster or redundant Routing Engines are detected on device)," +
+					" `always` or `never`." +
+					" May also be provided via " + junos.EnvCommitSynchronize + " environment variable." +
+					" Defaults to `auto`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						junos.CommitSynchronizeAuto, junos.CommitSynchronizeAlways, junos.CommitSynchronizeNever,
+					),
+				},
+			},
+			"config_mode": schema.StringAttribute{
+				Optional: true,
+				Description: "Mode to edit the candidate configuration:" +
+					" `shared` (lock of the shared candidate configuration)," +
+					" `private` (private candidate configuration like `configure private`)" +
+					" or `exclusive` (lock like `configure exclusive` and discard uncommitted changes" +
+					" before unlock)." +
+					" May also be provided via " + junos.EnvConfigMode + " environment variable." +
+					" Defaults to `shared`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(junos.ConfigModeShared, junos.ConfigModePrivate, junos.ConfigModeExclusive),
+				},
+			},
+			"plan_commit_check": schema.BoolAttribute{
+				Optional: true,
+				Description: "When planning, load the set/delete lines of each resource action" +
+					" in a private candidate configuration and run `commit check` to report errors" +
+					" as warnings before apply." +
+					" May also be enabled via " + junos.EnvPlanCommitCheck + " environment variable.",
+			},
+			"plan_junos_diff": schema.BoolAttribute{
+				Optional: true,
+				Description: "When planning, generate the set/delete lines of each resource action" +
+					" and show them in the `junos_diff` attribute of resources." +
+					" May also be enabled via " + junos.EnvPlanJunosDiff + " environment variable.",
+			},
 			"ssh_sleep_closed": schema.Int64Attribute{
 				Optional: true,
 				Description: "Seconds to wait after Terraform provider closed a ssh connection." +
@@ -187,6 +330,34 @@ func (p *junosProvider) Schema(
 					int64validator.Between(1, 10),
 				},
 			},
+			"ssh_host_keys": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Public keys (in authorized_keys format) trusted for the SSH host key of the device.",
+			},
+			"ssh_host_key_fingerprints": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "SHA-256 fingerprints (`SHA256:<base64>` or hexadecimal like SSHFP records)" +
+					" trusted for the SSH host key of the device.",
+			},
+			"ssh_known_hosts_file": schema.StringAttribute{
+				Optional: true,
+				Description: "Path to a known_hosts file to verify the SSH host key of the device." +
+					" May also be provided via " + junos.EnvSSHKnownHostsFile + " environment variable.",
+			},
+			"ssh_trust_on_first_use": schema.BoolAttribute{
+				Optional: true,
+				Description: "Add the SSH host key of the device in the `ssh_known_hosts_file` file" +
+					" when the device is unknown in it." +
+					" May also be enabled via " + junos.EnvSSHTrustOnFirstUse + " environment variable.",
+			},
+			"proxy": schema.StringAttribute{
+				Optional: true,
+				Description: "URL of a SOCKS5 (`socks5://` or `socks5h://`) or HTTP CONNECT (`http://`) proxy" +
+					" to go through to reach the device (or the first jump host)." +
+					" May also be provided via " + junos.EnvProxy + " environment variable.",
+			},
 			"file_permission": schema.StringAttribute{
 				Optional: true,
 				Description: "The permission to set for the created file (debug, setfile)." +
@@ -228,23 +399,272 @@ func (p *junosProvider) Schema(
 					"as Terraform shuts down the provider without prior notice." +
 					" May also be enabled via " + junos.EnvSingleSession + " environment variable.",
 			},
+			"session_pool_size": schema.Int64Attribute{
+				Optional: true,
+				Description: "Reuse SSH/NETCONF sessions between provider operations with a pool" +
+					" of at most this number of sessions opened at the same time." +
+					" An idle session is checked before being reused." +
+					" May also be provided via " + junos.EnvSessionPoolSize + " environment variable.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"session_pool_idle_timeout": schema.Int64Attribute{
+				Optional: true,
+				Description: "Seconds before closing an idle session of the pool (`0` to never close them)." +
+					" Defaults to `60`." +
+					" May also be provided via " + junos.EnvSessionPoolIdleTimeout + " environment variable.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"batch_commit": schema.BoolAttribute{
+				Optional: true,
+				Description: "Collect the set/delete lines of resource actions to load them" +
+					" in a private candidate configuration and commit them together" +
+					" with a single commit instead of one commit for each resource action." +
+					" May also be enabled via " + junos.EnvBatchCommit + " environment variable.",
+			},
+			"batch_commit_window": schema.Int64Attribute{
+				Optional: true,
+				Description: "Milliseconds to wait for other resource actions before the batch commit." +
+					" Defaults to `2000`." +
+					" May also be provided via " + junos.EnvBatchCommitWindow + " environment variable.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"devices": schema.MapNestedAttribute{
+				Optional: true,
+				Description: "Named Junos devices which can be selected with the `device` argument" +
+					" of resources and data sources." +
+					" Options not set for a device are inherited from the provider.",
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z0-9._-]+$`),
+							"must only contain letters, digits, dots, underscores and dashes"),
+					),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"ip": schema.StringAttribute{
+							Required:    true,
+							Description: "The target for Netconf session to the device (ip or dns name).",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"port": schema.Int64Attribute{
+							Optional:    true,
+							Description: "The tcp port for ssh connection to the device.",
+							Validators: []validator.Int64{
+								int64validator.Between(1, 65535),
+							},
+						},
+						"username": schema.StringAttribute{
+							Optional:    true,
+							Description: "The username for ssh connection to the device.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"password": schema.StringAttribute{
+							Optional:    true,
+							Description: "The password for ssh connection to the device.",
+						},
+						"sshkey_pem": schema.StringAttribute{
+							Optional:    true,
+							Description: "The ssh key in PEM format for ssh connection to the device.",
+						},
+						"sshkeyfile": schema.StringAttribute{
+							Optional:    true,
+							Description: "The path to ssh key for ssh connection to the device.",
+						},
+						"keypass": schema.StringAttribute{
+							Optional:    true,
+							Description: "The passphrase for open `sshkeyfile` or `sshkey_pem` of the device.",
+						},
+						"sshcert_pem": schema.StringAttribute{
+							Optional:    true,
+							Description: "The OpenSSH user certificate signed for the ssh key of the device.",
+						},
+						"sshcertfile": schema.StringAttribute{
+							Optional:    true,
+							Description: "The path to OpenSSH user certificate signed for the ssh key of the device.",
+						},
+						"ssh_host_keys": schema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Public keys (in authorized_keys format) trusted for the SSH host key of the device" +
+								" instead of the `ssh_host_keys` and `ssh_host_key_fingerprints` of provider.",
+						},
+						"ssh_host_key_fingerprints": schema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "SHA-256 fingerprints (`SHA256:<base64>` or hexadecimal like SSHFP records)" +
+								" trusted for the SSH host key of the device" +
+								" instead of the `ssh_host_keys` and `ssh_host_key_fingerprints` of provider.",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"ssh_jump_host": schema.ListNestedBlock{
+				Description: "For each jump host (SSH server) to go through, in order, to reach the Junos device.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"ip": schema.StringAttribute{
+							Required:    true,
+							Description: "The target for SSH connection to the jump host (ip or dns name).",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"port": schema.Int64Attribute{
+							Optional:    true,
+							Description: "The tcp port for SSH connection to the jump host.",
+							Validators: []validator.Int64{
+								int64validator.Between(1, 65535),
+							},
+						},
+						"username": schema.StringAttribute{
+							Optional:    true,
+							Description: "The username for SSH connection to the jump host.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"password": schema.StringAttribute{
+							Optional:    true,
+							Description: "The password for SSH connection to the jump host.",
+						},
+						"sshkey_pem": schema.StringAttribute{
+							Optional:    true,
+							Description: "The SSH key in PEM format for SSH connection to the jump host.",
+						},
+						"sshkeyfile": schema.StringAttribute{
+							Optional:    true,
+							Description: "The path to SSH key for SSH connection to the jump host.",
+						},
+						"keypass": schema.StringAttribute{
+							Optional:    true,
+							Description: "The passphrase for open `sshkeyfile` or `sshkey_pem` of the jump host.",
+						},
+						"sshcert_pem": schema.StringAttribute{
+							Optional:    true,
+							Description: "The OpenSSH user certificate signed for the SSH key of the jump host.",
+						},
+						"sshcertfile": schema.StringAttribute{
+							Optional:    true,
+							Description: "The path to OpenSSH user certificate signed for the SSH key of the jump host.",
+						},
+						"ssh_host_keys": schema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "Public keys (in authorized_keys format) trusted for the SSH host key of the jump host.",
+						},
+						"ssh_host_key_fingerprints": schema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: "SHA-256 fingerprints (`SHA256:<base64>` or hexadecimal like SSHFP records)" +
+								" trusted for the SSH host key of the jump host.",
+						},
+						"ssh_known_hosts_file": schema.StringAttribute{
+							Optional: true,
+							Description: "Path to a known_hosts file to verify the SSH host key of the jump host." +
+								" Without any of `ssh_host_keys`, `ssh_host_key_fingerprints` and `ssh_known_hosts_file`" +
+								" in the block, the `ssh_known_hosts_file` of provider is used for the jump host.",
+						},
+						"ssh_trust_on_first_use": schema.BoolAttribute{
+							Optional: true,
+							Description: "Add the SSH host key of the jump host in the `ssh_known_hosts_file` file" +
+								" of the block when the jump host is unknown in it.",
+							Validators: []validator.Bool{
+								boolvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("ssh_known_hosts_file")),
+							},
+						},
+					},
+				},
+			},
+			"commit_confirmed_health_probe": schema.ListNestedBlock{
+				Description: "For each health probe to run after a commit with `confirmed` option" +
+					" and before its confirmation." +
+					" If a probe fails, the commit is not confirmed and the device rolls back the configuration," +
+					" the next commits and confirmations are refused for the rest of run.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Optional:    true,
+							Description: "Name of probe in errors.",
+						},
+						"rpc": schema.StringAttribute{
+							Required:    true,
+							Description: "Operational RPC in XML to run.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"xpath": schema.StringAttribute{
+							Required:    true,
+							Description: "XPath to select the nodes in the reply of RPC, at least one node need to be found.",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"expected_value": schema.StringAttribute{
+							Optional:    true,
+							Description: "Value that all nodes selected by `xpath` need to have.",
+						},
+						"attempts": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Number of attempts before the probe fails. Defaults to 1.",
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+						"interval": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Seconds to wait between attempts. Defaults to 10.",
+							Validators: []validator.Int64{
+								int64validator.AtLeast(0),
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
 func (p *junosProvider) Actions(_ context.Context) []func() action.Action {
-	return []func() action.Action{
+	return actionsWithDevice([]func() action.Action{
+		newCommitAtAction,
+		newCommitAtClearAction,
+		newCommitConfirmAction,
 		newCommitFileAction,
 		newLoadConfigAction,
+		newRollbackAction,
+	})
+}
+
+func (p *junosProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		newDecodeSecret9Function,
+		newEncodeSecret9Function,
+		newHashPasswordSHA512Function,
 	}
 }
 
-func (p *junosProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{
+func (p *junosProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return append(dataSourcesWithDevice([]func() datasource.DataSource{
 		newApplicationSetsDataSource,
 		newApplicationsDataSource,
 		newChassisInventoryDataSource,
+		newCommitAtPendingDataSource,
+		newCommitHistoryDataSource,
 		newConfigRawDataSource,
+		newConfigUnmanagedDataSource(p.Resources(ctx)),
 		newInterfaceLogicalDataSource,
 		newInterfaceLogicalInfoDataSource,
 		newInterfacePhysicalDataSource,
@@ -258,11 +678,13 @@ func (p *junosProvider) DataSources(_ context.Context) []func() datasource.DataS
 		newRoutingInstanceDataSource,
 		newSecurityZoneDataSource,
 		newSystemInformationDataSource,
-	}
+	}),
+		newConfigImportDataSource(p.Resources(ctx)),
+	)
 }
 
 func (p *junosProvider) Resources(_ context.Context) []func() resource.Resource {
-	return []func() resource.Resource{
+	return resourcesWithDevice([]func() resource.Resource{
 		newAccessAddressAssignmentPoolResource,
 		newAggregateRouteResource,
 		newApplicationResource,
@@ -403,7 +825,11 @@ func (p *junosProvider) Resources(_ context.Context) []func() resource.Resource
 		newVstpInterfaceResource,
 		newVstpVlanResource,
 		newVstpVlanGroupResource,
-	}
+	})
+}
+
+func (p *junosProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return configListResources(p.Resources(ctx))
 }
 
 func (p *junosProvider) Configure( //nolint:gocyclo
@@ -451,6 +877,22 @@ func (p *junosProvider) Configure( //nolint:gocyclo
 				fmt.Sprintf(instructionUnknownMessage, junos.EnvPassword),
 		)
 	}
+	if config.OTP.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("otp"),
+			tfdiag.UnknownJunosAttrErrSummary,
+			unknownValueErrorMessage+"for 'otp' attribute."+
+				fmt.Sprintf(instructionUnknownMessage, junos.EnvOTP),
+		)
+	}
+	if config.OTPFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("otp_file"),
+			tfdiag.UnknownJunosAttrErrSummary,
+			unknownValueErrorMessage+"for 'otp_file' attribute."+
+				fmt.Sprintf(instructionUnknownMessage, junos.EnvOTPFile),
+		)
+	}
 	if config.SSHKeyPem.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("sshkey_pem"),
@@ -467,6 +909,22 @@ func (p *junosProvider) Configure( //nolint:gocyclo
 				fmt.Sprintf(instructionUnknownMessage, junos.EnvKeyFile),
 		)
 	}
+	if config.SSHCertPem.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("sshcert_pem"),
+			tfdiag.UnknownJunosAttrErrSummary,
+			unknownValueErrorMessage+"for 'sshcert_pem' attribute."+
+				fmt.Sprintf(instructionUnknownMessage, junos.EnvCertPem),
+		)
+	}
+	if config.SSHCertFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("sshcertfile"),
+			tfdiag.UnknownJunosAttrErrSummary,
+			unknownValueErrorMessage+"for 'sshcertfile' attribute."+
+				fmt.Sprintf(instructionUnknownMessage, junos.EnvCertFile),
+		)
+	}
 	if config.SSHKeyPass.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("keypass"),
@@ -523,6 +981,46 @@ func (p *junosProvider) Configure( //nolint:gocyclo
 				fmt.Sprintf(instructionUnknownMessage, junos.EnvCommitConfirmedWaitPercent),
 		)
 	}
+	if config.CommitConfirmedDeferred.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("commit_confirmed_deferred"),
+			tfdiag.UnknownJunosAttrErrSummary,
+			unknownValueErrorMessage+"for 'commit_confirmed_deferred' attribute."+
+				fmt.Sprintf(instructionUnknownMessage, junos.EnvCommitConfirmedDeferred),
+		)
+	}
+	if config.CommitSynchronize.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("commit_synchronize"),
+			tfdiag.UnknownJunosAttrErrSummary,
+			unknownValueErrorMessage+"for 'commit_synchronize' attribute."+
+				fmt.Sprintf(instructionUnknownMessage, junos.EnvCommitSynchronize),
+		)
+	}
+	if config.ConfigMode.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("config_mode"),
+			tfdiag.UnknownJunosAttrErrSummary,
+			unknownValueErrorMessage+"for 'config_mode' attribute."+
+				fmt.Sprintf(instructionUnknownMessage, junos.EnvConfigMode),
+		)
+	}
+	if config.PlanCommitCheck.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("plan_commit_check"),
+			tfdiag.UnknownJunosAttrErrSummary,
+			unknownValueErrorMessage+"for 'plan_commit_check' attribute."+
+				fmt.Sprintf(instructionUnknownMessage, junos.EnvPlanCommitCheck),
+		)
+	}
+	if config.PlanJunosDiff.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("plan_junos_diff"),
+			tfdiag.UnknownJunosAttrErrSummary,
+			unknownValueErrorMessage+"for 'plan_junos_diff' attribute."+
+				fmt.Sprintf(instructionUnknownMessage, junos.EnvPlanJunosDiff),
+		)
+	}
 	if config.SleepSSHClosed.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("ssh_sleep_closed"),
@@ -565,6 +1063,127 @@ func (p *junosProvider) Configure( //nolint:gocyclo
 				fmt.Sprintf(instructionUnknownMessage, junos.EnvSSHRetryToEstablish),
 		)
 	}
+	if config.SSHHostKeys.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ssh_host_keys"),
+			tfdiag.UnknownJunosAttrErrSummary,
+			unknownValueErrorMessage+"for 'ssh_host_keys' attribute."+
+				" Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+	for _, v := range config.SSHHostKeys.Elements() {
+		if v.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ssh_host_keys"),
+				tfdiag.UnknownJunosAttrErrSummary,
+				unknownValueErrorMessage+"for 'ssh_host_keys' attribute."+
+					" Either target apply the source of the value first or set the value statically in the configuration.",
+			)
+		}
+	}
+	if config.SSHHostKeyFingerprints.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ssh_host_key_fingerprints"),
+			tfdiag.UnknownJunosAttrErrSummary,
+			unknownValueErrorMessage+"for 'ssh_host_key_fingerprints' attribute."+
+				" Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+	for _, v := range config.SSHHostKeyFingerprints.Elements() {
+		if v.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ssh_host_key_fingerprints"),
+				tfdiag.UnknownJunosAttrErrSummary,
+				unknownValueErrorMessage+"for 'ssh_host_key_fingerprints' attribute."+
+					" Either target apply the source of the value first or set the value statically in the configuration.",
+			)
+		}
+	}
+	if config.SSHKnownHostsFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ssh_known_hosts_file"),
+			tfdiag.UnknownJunosAttrErrSummary,
+			unknownValueErrorMessage+"for 'ssh_known_hosts_file' attribute."+
+				fmt.Sprintf(instructionUnknownMessage, junos.EnvSSHKnownHostsFile),
+		)
+	}
+	if config.SSHTrustOnFirstUse.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ssh_trust_on_first_use"),
+			tfdiag.UnknownJunosAttrErrSummary,
+			unknownValueErrorMessage+"for 'ssh_trust_on_first_use' attribute."+
+				fmt.Sprintf(instructionUnknownMessage, junos.EnvSSHTrustOnFirstUse),
+		)
+	}
+	if config.Proxy.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("proxy"),
+			tfdiag.UnknownJunosAttrErrSummary,
+			unknownValueErrorMessage+"for 'proxy' attribute."+
+				fmt.Sprintf(instructionUnknownMessage, junos.EnvProxy),
+		)
+	}
+	for i, block := range config.SSHJumpHost {
+		if block.IP.IsUnknown() ||
+			block.Port.IsUnknown() ||
+			block.Username.IsUnknown() ||
+			block.Password.IsUnknown() ||
+			block.SSHKeyPem.IsUnknown() ||
+			block.SSHKeyFile.IsUnknown() ||
+			block.SSHKeyPass.IsUnknown() ||
+			block.SSHCertPem.IsUnknown() ||
+			block.SSHCertFile.IsUnknown() ||
+			block.SSHHostKeys.IsUnknown() ||
+			block.SSHHostKeyFingerprints.IsUnknown() ||
+			block.SSHKnownHostsFile.IsUnknown() ||
+			block.SSHTrustOnFirstUse.IsUnknown() ||
+			slices.ContainsFunc(block.SSHHostKeys.Elements(), attr.Value.IsUnknown) ||
+			slices.ContainsFunc(block.SSHHostKeyFingerprints.Elements(), attr.Value.IsUnknown) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ssh_jump_host").AtListIndex(i),
+				tfdiag.UnknownJunosAttrErrSummary,
+				unknownValueErrorMessage+"in 'ssh_jump_host' block."+
+					" Either target apply the source of the value first or set the value statically in the configuration.",
+			)
+		}
+	}
+	for i, block := range config.CommitConfirmedProbe {
+		if block.Name.IsUnknown() ||
+			block.RPC.IsUnknown() ||
+			block.XPath.IsUnknown() ||
+			block.ExpectedValue.IsUnknown() ||
+			block.Attempts.IsUnknown() ||
+			block.Interval.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("commit_confirmed_health_probe").AtListIndex(i),
+				tfdiag.UnknownJunosAttrErrSummary,
+				unknownValueErrorMessage+"in 'commit_confirmed_health_probe' block."+
+					" Either target apply the source of the value first or set the value statically in the configuration.",
+			)
+		}
+	}
+	for name, device := range config.Devices {
+		if device.IP.IsUnknown() ||
+			device.Port.IsUnknown() ||
+			device.Username.IsUnknown() ||
+			device.Password.IsUnknown() ||
+			device.SSHKeyPem.IsUnknown() ||
+			device.SSHKeyFile.IsUnknown() ||
+			device.SSHKeyPass.IsUnknown() ||
+			device.SSHCertPem.IsUnknown() ||
+			device.SSHCertFile.IsUnknown() ||
+			device.SSHHostKeys.IsUnknown() ||
+			device.SSHHostKeyFingerprints.IsUnknown() ||
+			slices.ContainsFunc(device.SSHHostKeys.Elements(), attr.Value.IsUnknown) ||
+			slices.ContainsFunc(device.SSHHostKeyFingerprints.Elements(), attr.Value.IsUnknown) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("devices").AtMapKey(name),
+				tfdiag.UnknownJunosAttrErrSummary,
+				unknownValueErrorMessage+"in '"+name+"' device of 'devices' argument."+
+					" Either target apply the source of the value first or set the value statically in the configuration.",
+			)
+		}
+	}
 	if config.FilePermission.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("file_permission"),
@@ -613,6 +1232,38 @@ func (p *junosProvider) Configure( //nolint:gocyclo
 				fmt.Sprintf(instructionUnknownMessage, junos.EnvSingleSession),
 		)
 	}
+	if config.SessionPoolSize.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("session_pool_size"),
+			tfdiag.UnknownJunosAttrErrSummary,
+			unknownValueErrorMessage+"for 'session_pool_size' attribute."+
+				fmt.Sprintf(instructionUnknownMessage, junos.EnvSessionPoolSize),
+		)
+	}
+	if config.SessionPoolIdleTimeout.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("session_pool_idle_timeout"),
+			tfdiag.UnknownJunosAttrErrSummary,
+			unknownValueErrorMessage+"for 'session_pool_idle_timeout' attribute."+
+				fmt.Sprintf(instructionUnknownMessage, junos.EnvSessionPoolIdleTimeout),
+		)
+	}
+	if config.BatchCommit.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("batch_commit"),
+			tfdiag.UnknownJunosAttrErrSummary,
+			unknownValueErrorMessage+"for 'batch_commit' attribute."+
+				fmt.Sprintf(instructionUnknownMessage, junos.EnvBatchCommit),
+		)
+	}
+	if config.BatchCommitWindow.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("batch_commit_window"),
+			tfdiag.UnknownJunosAttrErrSummary,
+			unknownValueErrorMessage+"for 'batch_commit_window' attribute."+
+				fmt.Sprintf(instructionUnknownMessage, junos.EnvBatchCommitWindow),
+		)
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -621,12 +1272,13 @@ func (p *junosProvider) Configure( //nolint:gocyclo
 	if !config.IP.IsNull() {
 		hostIP = config.IP.ValueString()
 	}
-	if hostIP == "" {
+	if hostIP == "" && len(config.Devices) == 0 {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("ip"),
 			"Missing Junos IP target",
 			"The provider cannot create the Junos client as there is a missing or empty value for the Junos IP."+
-				" Set the ip value in the configuration or use the "+junos.EnvHost+" environment variable."+
+				" Set the ip value in the configuration or use the "+junos.EnvHost+" environment variable"+
+				" (or set the devices argument)."+
 				" If either is already set, ensure the value is not empty.",
 		)
 
@@ -665,6 +1317,37 @@ func (p *junosProvider) Configure( //nolint:gocyclo
 		client.WithPassword(v)
 	}
 
+	if !config.OTP.IsNull() {
+		client.WithOTP(config.OTP.ValueString())
+	} else if v := os.Getenv(junos.EnvOTP); v != "" {
+		client.WithOTP(v)
+	}
+
+	if !config.OTPFile.IsNull() {
+		otpFile := config.OTPFile.ValueString()
+		if err := utils.ReplaceTildeToHomeDir(&otpFile); err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("otp_file"),
+				"Bad value in otp_file",
+				fmt.Sprintf("Error to use value in otp_file attribute: %s\n"+
+					"So the attribute is not used", err),
+			)
+		} else {
+			client.WithOTPFile(otpFile)
+		}
+	} else if v := os.Getenv(junos.EnvOTPFile); v != "" {
+		if err := utils.ReplaceTildeToHomeDir(&v); err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("otp_file"),
+				"Bad value in "+junos.EnvOTPFile,
+				fmt.Sprintf("Error to use value in "+junos.EnvOTPFile+" environment variable: %s\n"+
+					"So the variable is not used", err),
+			)
+		} else {
+			client.WithOTPFile(v)
+		}
+	}
+
 	if !config.SSHKeyPem.IsNull() {
 		client.WithSSHKeyPEM(config.SSHKeyPem.ValueString())
 	} else if v := os.Getenv(junos.EnvKeyPem); v != "" {
@@ -696,6 +1379,37 @@ func (p *junosProvider) Configure( //nolint:gocyclo
 		}
 	}
 
+	if !config.SSHCertPem.IsNull() {
+		client.WithSSHCertPEM(config.SSHCertPem.ValueString())
+	} else if v := os.Getenv(junos.EnvCertPem); v != "" {
+		client.WithSSHCertPEM(v)
+	}
+
+	if !config.SSHCertFile.IsNull() {
+		certFile := config.SSHCertFile.ValueString()
+		if err := utils.ReplaceTildeToHomeDir(&certFile); err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("sshcertfile"),
+				"Bad value in sshcertfile",
+				fmt.Sprintf("Error to use value in sshcertfile attribute: %s\n"+
+					"So the attribute is not used", err),
+			)
+		} else {
+			client.WithSSHCertFile(certFile)
+		}
+	} else if v := os.Getenv(junos.EnvCertFile); v != "" {
+		if err := utils.ReplaceTildeToHomeDir(&v); err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("sshcertfile"),
+				"Bad value in "+junos.EnvCertFile,
+				fmt.Sprintf("Error to use value in "+junos.EnvCertFile+" environment variable: %s\n"+
+					"So the variable is not used", err),
+			)
+		} else {
+			client.WithSSHCertFile(v)
+		}
+	}
+
 	if !config.SSHKeyPass.IsNull() {
 		client.WithSSHKeyPassphrase(config.SSHKeyPass.ValueString())
 	} else if v := os.Getenv(junos.EnvKeyPass); v != "" {
@@ -811,6 +1525,99 @@ func (p *junosProvider) Configure( //nolint:gocyclo
 		}
 	}
 
+	commitConfirmedDeferred := utils.ParseTrue(os.Getenv(junos.EnvCommitConfirmedDeferred))
+	if !config.CommitConfirmedDeferred.IsNull() {
+		commitConfirmedDeferred = config.CommitConfirmedDeferred.ValueBool()
+	}
+	if commitConfirmedDeferred {
+		if _, err := client.WithCommitConfirmedDeferred(); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("commit_confirmed_deferred"),
+				"Missing commit_confirmed",
+				fmt.Sprintf("Error to use 'commit_confirmed_deferred' attribute (or environment variable): %s", err),
+			)
+		}
+	}
+
+	if len(config.CommitConfirmedProbe) > 0 {
+		probes := make([]junos.HealthProbe, len(config.CommitConfirmedProbe))
+		for i, block := range config.CommitConfirmedProbe {
+			probes[i] = junos.HealthProbe{
+				Name:          block.Name.ValueString(),
+				RPC:           block.RPC.ValueString(),
+				XPath:         block.XPath.ValueString(),
+				ExpectedValue: block.ExpectedValue.ValueString(),
+				Attempts:      1,                // default value for attempts of probe
+				Interval:      10 * time.Second, // default value for interval of probe
+			}
+			if !block.Attempts.IsNull() {
+				probes[i].Attempts = int(block.Attempts.ValueInt64())
+			}
+			if !block.Interval.IsNull() {
+				probes[i].Interval = time.Duration(block.Interval.ValueInt64()) * time.Second
+			}
+		}
+		if _, err := client.WithHealthProbes(probes); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("commit_confirmed_health_probe"),
+				"Bad value in commit_confirmed_health_probe",
+				fmt.Sprintf("Error to use 'commit_confirmed_health_probe' blocks: %s", err),
+			)
+		}
+	}
+
+	if !config.CommitSynchronize.IsNull() {
+		if _, err := client.WithCommitSynchronize(config.CommitSynchronize.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("commit_synchronize"),
+				"Bad value in commit_synchronize",
+				fmt.Sprintf("Error to use value in 'commit_synchronize' attribute: %s", err),
+			)
+		}
+	} else if v := os.Getenv(junos.EnvCommitSynchronize); v != "" {
+		if _, err := client.WithCommitSynchronize(v); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("commit_synchronize"),
+				"Bad value in "+junos.EnvCommitSynchronize,
+				fmt.Sprintf("Error to use value in "+junos.EnvCommitSynchronize+" environment variable: %s", err),
+			)
+		}
+	}
+
+	if !config.ConfigMode.IsNull() {
+		if _, err := client.WithConfigMode(config.ConfigMode.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("config_mode"),
+				"Bad value in config_mode",
+				fmt.Sprintf("Error to use value in 'config_mode' attribute: %s", err),
+			)
+		}
+	} else if v := os.Getenv(junos.EnvConfigMode); v != "" {
+		if _, err := client.WithConfigMode(v); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("config_mode"),
+				"Bad value in "+junos.EnvConfigMode,
+				fmt.Sprintf("Error to use value in "+junos.EnvConfigMode+" environment variable: %s", err),
+			)
+		}
+	}
+
+	if !config.PlanCommitCheck.IsNull() {
+		if config.PlanCommitCheck.ValueBool() {
+			client.WithPlanCommitCheck()
+		}
+	} else if utils.ParseTrue(os.Getenv(junos.EnvPlanCommitCheck)) {
+		client.WithPlanCommitCheck()
+	}
+
+	if !config.PlanJunosDiff.IsNull() {
+		if config.PlanJunosDiff.ValueBool() {
+			client.WithPlanJunosDiff()
+		}
+	} else if utils.ParseTrue(os.Getenv(junos.EnvPlanJunosDiff)) {
+		client.WithPlanJunosDiff()
+	}
+
 	if !config.SleepSSHClosed.IsNull() {
 		client.WithSleepSSHClosed(int(config.SleepSSHClosed.ValueInt64()))
 	} else if v := os.Getenv(junos.EnvSleepSSHClosed); v != "" {
@@ -883,6 +1690,173 @@ func (p *junosProvider) Configure( //nolint:gocyclo
 		}
 	}
 
+	if !config.SSHHostKeys.IsNull() && len(config.SSHHostKeys.Elements()) > 0 {
+		sshHostKeys := make([]string, len(config.SSHHostKeys.Elements()))
+		for i, v := range config.SSHHostKeys.Elements() {
+			sshHostKeys[i] = v.(types.String).ValueString()
+		}
+		if _, err := client.WithSSHHostKeys(sshHostKeys); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ssh_host_keys"),
+				"Bad value in ssh_host_keys",
+				fmt.Sprintf("Error to use value in ssh_host_keys attribute: %s", err),
+			)
+		}
+	}
+
+	if !config.SSHHostKeyFingerprints.IsNull() && len(config.SSHHostKeyFingerprints.Elements()) > 0 {
+		sshHostKeyFingerprints := make([]string, len(config.SSHHostKeyFingerprints.Elements()))
+		for i, v := range config.SSHHostKeyFingerprints.Elements() {
+			sshHostKeyFingerprints[i] = v.(types.String).ValueString()
+		}
+		if _, err := client.WithSSHHostKeyFingerprints(sshHostKeyFingerprints); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ssh_host_key_fingerprints"),
+				"Bad value in ssh_host_key_fingerprints",
+				fmt.Sprintf("Error to use value in ssh_host_key_fingerprints attribute: %s", err),
+			)
+		}
+	}
+
+	if !config.SSHKnownHostsFile.IsNull() {
+		knownHostsFile := config.SSHKnownHostsFile.ValueString()
+		if err := utils.ReplaceTildeToHomeDir(&knownHostsFile); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ssh_known_hosts_file"),
+				"Bad value in ssh_known_hosts_file",
+				fmt.Sprintf("Error to use value in ssh_known_hosts_file attribute: %s", err),
+			)
+		} else {
+			client.WithSSHKnownHostsFile(knownHostsFile)
+		}
+	} else if v := os.Getenv(junos.EnvSSHKnownHostsFile); v != "" {
+		if err := utils.ReplaceTildeToHomeDir(&v); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ssh_known_hosts_file"),
+				"Bad value in "+junos.EnvSSHKnownHostsFile,
+				fmt.Sprintf("Error to use value in "+junos.EnvSSHKnownHostsFile+" environment variable: %s", err),
+			)
+		} else {
+			client.WithSSHKnownHostsFile(v)
+		}
+	}
+
+	trustOnFirstUse := false
+	if !config.SSHTrustOnFirstUse.IsNull() {
+		trustOnFirstUse = config.SSHTrustOnFirstUse.ValueBool()
+	} else if utils.ParseTrue(os.Getenv(junos.EnvSSHTrustOnFirstUse)) {
+		trustOnFirstUse = true
+	}
+	if trustOnFirstUse {
+		if config.SSHKnownHostsFile.IsNull() && os.Getenv(junos.EnvSSHKnownHostsFile) == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ssh_trust_on_first_use"),
+				tfdiag.MissingConfigErrSummary,
+				"'ssh_known_hosts_file' need to be set with 'ssh_trust_on_first_use'",
+			)
+		}
+		client.WithSSHTrustOnFirstUse()
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(config.SSHJumpHost) > 0 {
+		sshJumpHosts := make([]junos.SSHJumpHost, len(config.SSHJumpHost))
+		for i, block := range config.SSHJumpHost {
+			sshJumpHosts[i] = junos.SSHJumpHost{
+				IP:         block.IP.ValueString(),
+				Port:       22, // default value for port of jump host
+				UserName:   block.Username.ValueString(),
+				Password:   block.Password.ValueString(),
+				SSHKeyPEM:  block.SSHKeyPem.ValueString(),
+				SSHKeyPass: block.SSHKeyPass.ValueString(),
+				SSHCertPEM: block.SSHCertPem.ValueString(),
+			}
+			if !block.Port.IsNull() {
+				sshJumpHosts[i].Port = int(block.Port.ValueInt64())
+			}
+			if !block.SSHKeyFile.IsNull() {
+				keyFile := block.SSHKeyFile.ValueString()
+				if err := utils.ReplaceTildeToHomeDir(&keyFile); err != nil {
+					resp.Diagnostics.AddAttributeError(
+						path.Root("ssh_jump_host").AtListIndex(i).AtName("sshkeyfile"),
+						"Bad value in sshkeyfile",
+						fmt.Sprintf("Error to use value in sshkeyfile attribute of ssh_jump_host block: %s", err),
+					)
+
+					return
+				}
+				sshJumpHosts[i].SSHKeyFile = keyFile
+			}
+			if !block.SSHCertFile.IsNull() {
+				certFile := block.SSHCertFile.ValueString()
+				if err := utils.ReplaceTildeToHomeDir(&certFile); err != nil {
+					resp.Diagnostics.AddAttributeError(
+						path.Root("ssh_jump_host").AtListIndex(i).AtName("sshcertfile"),
+						"Bad value in sshcertfile",
+						fmt.Sprintf("Error to use value in sshcertfile attribute of ssh_jump_host block: %s", err),
+					)
+
+					return
+				}
+				sshJumpHosts[i].SSHCertFile = certFile
+			}
+			for _, v := range block.SSHHostKeys.Elements() {
+				sshJumpHosts[i].HostKeys = append(sshJumpHosts[i].HostKeys, v.(types.String).ValueString())
+			}
+			for _, v := range block.SSHHostKeyFingerprints.Elements() {
+				sshJumpHosts[i].HostKeyFingerprints = append(sshJumpHosts[i].HostKeyFingerprints,
+					v.(types.String).ValueString())
+			}
+			if !block.SSHKnownHostsFile.IsNull() {
+				knownHostsFile := block.SSHKnownHostsFile.ValueString()
+				if err := utils.ReplaceTildeToHomeDir(&knownHostsFile); err != nil {
+					resp.Diagnostics.AddAttributeError(
+						path.Root("ssh_jump_host").AtListIndex(i).AtName("ssh_known_hosts_file"),
+						"Bad value in ssh_known_hosts_file",
+						fmt.Sprintf("Error to use value in ssh_known_hosts_file attribute of ssh_jump_host block: %s", err),
+					)
+
+					return
+				}
+				sshJumpHosts[i].KnownHostsFile = knownHostsFile
+			}
+			sshJumpHosts[i].TrustOnFirstUse = block.SSHTrustOnFirstUse.ValueBool()
+		}
+		if _, err := client.WithSSHJumpHosts(sshJumpHosts); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ssh_jump_host"),
+				"Bad value in ssh_jump_host",
+				err.Error(),
+			)
+
+			return
+		}
+	}
+
+	if !config.Proxy.IsNull() {
+		if _, err := client.WithProxy(config.Proxy.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("proxy"),
+				"Bad value in proxy",
+				fmt.Sprintf("Error to use value in proxy attribute: %s", err),
+			)
+
+			return
+		}
+	} else if v := os.Getenv(junos.EnvProxy); v != "" {
+		if _, err := client.WithProxy(v); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("proxy"),
+				"Bad value in "+junos.EnvProxy,
+				fmt.Sprintf("Error to use value in "+junos.EnvProxy+" environment variable: %s", err),
+			)
+
+			return
+		}
+	}
+
 	_, _ = client.WithFilePermission(0o644) // default value for file_permission
 	if !config.FilePermission.IsNull() {
 		filePerm, err := strconv.ParseInt(config.FilePermission.ValueString(), 8, 64)
@@ -949,6 +1923,33 @@ func (p *junosProvider) Configure( //nolint:gocyclo
 		}
 	}
 
+	if v := os.Getenv(junos.EnvNetconfRecordFile); v != "" {
+		if err := utils.ReplaceTildeToHomeDir(&v); err != nil {
+			resp.Diagnostics.AddError(
+				"Bad value in "+junos.EnvNetconfRecordFile,
+				fmt.Sprintf("Error to use value in "+junos.EnvNetconfRecordFile+" environment variable: %s", err),
+			)
+		} else if _, err := client.WithNetconfRecordFile(v); err != nil {
+			resp.Diagnostics.AddError(
+				"Bad value in "+junos.EnvNetconfRecordFile,
+				fmt.Sprintf("Error to use value in "+junos.EnvNetconfRecordFile+" environment variable: %s", err),
+			)
+		}
+	}
+	if v := os.Getenv(junos.EnvNetconfReplayFile); v != "" {
+		if err := utils.ReplaceTildeToHomeDir(&v); err != nil {
+			resp.Diagnostics.AddError(
+				"Bad value in "+junos.EnvNetconfReplayFile,
+				fmt.Sprintf("Error to use value in "+junos.EnvNetconfReplayFile+" environment variable: %s", err),
+			)
+		} else if _, err := client.WithNetconfReplayFile(v); err != nil {
+			resp.Diagnostics.AddError(
+				"Bad value in "+junos.EnvNetconfReplayFile,
+				fmt.Sprintf("Error to use value in "+junos.EnvNetconfReplayFile+" environment variable: %s", err),
+			)
+		}
+	}
+
 	if !config.FakeCreateSetFile.IsNull() {
 		setFile := config.FakeCreateSetFile.ValueString()
 		if err := utils.ReplaceTildeToHomeDir(&setFile); err != nil {
@@ -1009,8 +2010,161 @@ func (p *junosProvider) Configure( //nolint:gocyclo
 		client.WithSingleSession()
 	}
 
+	sessionPoolSize := 0
+	if !config.SessionPoolSize.IsNull() {
+		sessionPoolSize = int(config.SessionPoolSize.ValueInt64())
+	} else if v := os.Getenv(junos.EnvSessionPoolSize); v != "" {
+		d, err := strconv.Atoi(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("session_pool_size"),
+				"Error to parse "+junos.EnvSessionPoolSize,
+				fmt.Sprintf("Error to parse value in "+junos.EnvSessionPoolSize+" environment variable: %s\n"+
+					"So the variable is not used", err),
+			)
+		} else {
+			sessionPoolSize = d
+		}
+	}
+	sessionPoolIdleTimeout := 60 // default value for session_pool_idle_timeout
+	if !config.SessionPoolIdleTimeout.IsNull() {
+		sessionPoolIdleTimeout = int(config.SessionPoolIdleTimeout.ValueInt64())
+	} else if v := os.Getenv(junos.EnvSessionPoolIdleTimeout); v != "" {
+		d, err := strconv.Atoi(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("session_pool_idle_timeout"),
+				"Error to parse "+junos.EnvSessionPoolIdleTimeout,
+				fmt.Sprintf("Error to parse value in "+junos.EnvSessionPoolIdleTimeout+" environment variable: %s\n"+
+					"So the variable is not used", err),
+			)
+		} else {
+			sessionPoolIdleTimeout = d
+		}
+	}
+	if sessionPoolSize != 0 {
+		if client.SingleSession() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("session_pool_size"),
+				"Conflict session attributes",
+				"'session_pool_size' cannot be used with 'single_session'",
+			)
+
+			return
+		}
+		if _, err := client.WithSessionPool(sessionPoolSize, sessionPoolIdleTimeout); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("session_pool_size"),
+				"Bad value in session pool attributes",
+				fmt.Sprintf("Error to use value in 'session_pool_size' or 'session_pool_idle_timeout' attributes"+
+					" (or environment variables): %s", err),
+			)
+
+			return
+		}
+	}
+
+	batchCommit := utils.ParseTrue(os.Getenv(junos.EnvBatchCommit))
+	if !config.BatchCommit.IsNull() {
+		batchCommit = config.BatchCommit.ValueBool()
+	}
+	batchCommitWindow := 2000 // default value for batch_commit_window
+	if !config.BatchCommitWindow.IsNull() {
+		batchCommitWindow = int(config.BatchCommitWindow.ValueInt64())
+	} else if v := os.Getenv(junos.EnvBatchCommitWindow); v != "" {
+		d, err := strconv.Atoi(v)
+		if err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("batch_commit_window"),
+				"Error to parse "+junos.EnvBatchCommitWindow,
+				fmt.Sprintf("Error to parse value in "+junos.EnvBatchCommitWindow+" environment variable: %s\n"+
+					"So the variable is not used", err),
+			)
+		} else {
+			batchCommitWindow = d
+		}
+	}
+	if batchCommit {
+		if client.SingleSession() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("batch_commit"),
+				"Conflict session attributes",
+				"'batch_commit' cannot be used with 'single_session'",
+			)
+
+			return
+		}
+		if _, err := client.WithBatchCommit(batchCommitWindow); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("batch_commit_window"),
+				"Bad value in batch_commit_window",
+				fmt.Sprintf("Error to use value in 'batch_commit_window' attribute (or environment variable): %s", err),
+			)
+
+			return
+		}
+	}
+
+	if len(config.Devices) > 0 {
+		devices := make(map[string]junos.Device, len(config.Devices))
+		for name, device := range config.Devices {
+			dev := junos.Device{
+				IP:         device.IP.ValueString(),
+				Port:       int(device.Port.ValueInt64()),
+				UserName:   device.Username.ValueString(),
+				Password:   device.Password.ValueString(),
+				SSHKeyPEM:  device.SSHKeyPem.ValueString(),
+				SSHKeyPass: device.SSHKeyPass.ValueString(),
+				SSHCertPEM: device.SSHCertPem.ValueString(),
+			}
+			if !device.SSHKeyFile.IsNull() {
+				keyFile := device.SSHKeyFile.ValueString()
+				if err := utils.ReplaceTildeToHomeDir(&keyFile); err != nil {
+					resp.Diagnostics.AddAttributeError(
+						path.Root("devices").AtMapKey(name).AtName("sshkeyfile"),
+						"Bad value in sshkeyfile",
+						fmt.Sprintf("Error to use value in sshkeyfile attribute of '%s' device: %s", name, err),
+					)
+
+					return
+				}
+				dev.SSHKeyFile = keyFile
+			}
+			if !device.SSHCertFile.IsNull() {
+				certFile := device.SSHCertFile.ValueString()
+				if err := utils.ReplaceTildeToHomeDir(&certFile); err != nil {
+					resp.Diagnostics.AddAttributeError(
+						path.Root("devices").AtMapKey(name).AtName("sshcertfile"),
+						"Bad value in sshcertfile",
+						fmt.Sprintf("Error to use value in sshcertfile attribute of '%s' device: %s", name, err),
+					)
+
+					return
+				}
+				dev.SSHCertFile = certFile
+			}
+			for _, v := range device.SSHHostKeys.Elements() {
+				dev.HostKeys = append(dev.HostKeys, v.(types.String).ValueString())
+			}
+			for _, v := range device.SSHHostKeyFingerprints.Elements() {
+				dev.HostKeyFingerprints = append(dev.HostKeyFingerprints, v.(types.String).ValueString())
+			}
+			devices[name] = dev
+		}
+		if _, err := client.WithDevices(devices); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("devices"),
+				"Bad value in devices",
+				fmt.Sprintf("Error to use value in devices attribute: %s", err),
+			)
+
+			return
+		}
+	}
+
 	resp.ActionData = client
 	resp.DataSourceData = client
+	resp.ListResourceData = client
 	resp.ResourceData = client
 }
 
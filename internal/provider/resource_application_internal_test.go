@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestApplicationResourceWithSimulator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	srv, client := newSimulatorClient(t)
+	rsc := newTestResource(ctx, newApplicationResource, client)
+
+	// create
+	plan := rsc.newPlan(ctx, t, &applicationData{
+		applicationAttrData: applicationAttrData{
+			Name:            types.StringValue("app1"),
+			Protocol:        types.StringValue("tcp"),
+			DestinationPort: types.StringValue("22"),
+		},
+	})
+	createResp := resource.CreateResponse{State: rsc.nullState(ctx)}
+	rsc.Create(ctx, resource.CreateRequest{
+		Config: planConfig(plan),
+		Plan:   plan,
+	}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("got unexpected error on create: %v", createResp.Diagnostics)
+	}
+	expectConfig := []string{
+		"set applications application app1 destination-port 22",
+		"set applications application app1 protocol tcp",
+	}
+	if v := srv.Config(); !sameLines(v, expectConfig) {
+		t.Errorf("got unexpected config after create: %q, want %q", v, expectConfig)
+	}
+
+	// create again with the same name
+	createAgainResp := resource.CreateResponse{State: rsc.nullState(ctx)}
+	rsc.Create(ctx, resource.CreateRequest{
+		Config: planConfig(plan),
+		Plan:   plan,
+	}, &createAgainResp)
+	if !createAgainResp.Diagnostics.HasError() {
+		t.Errorf("expected error on create of an existing application but got none")
+	}
+
+	// read
+	readResp := resource.ReadResponse{State: createResp.State}
+	rsc.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("got unexpected error on read: %v", readResp.Diagnostics)
+	}
+	var readData applicationData
+	readResp.State.Get(ctx, &readData)
+	if readData.ID.ValueString() != "app1" ||
+		readData.Protocol.ValueString() != "tcp" ||
+		readData.DestinationPort.ValueString() != "22" {
+		t.Errorf("got unexpected state after read: %+v", readData)
+	}
+
+	// update
+	planUpdate := rsc.newPlan(ctx, t, &applicationData{
+		applicationAttrData: applicationAttrData{
+			Name:        types.StringValue("app1"),
+			Protocol:    types.StringValue("udp"),
+			Description: types.StringValue("app with udp"),
+		},
+	})
+	updateResp := resource.UpdateResponse{State: readResp.State}
+	rsc.Update(ctx, resource.UpdateRequest{
+		Config: planConfig(planUpdate),
+		Plan:   planUpdate,
+		State:  readResp.State,
+	}, &updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("got unexpected error on update: %v", updateResp.Diagnostics)
+	}
+	expectConfig = []string{
+		"set applications application app1 description \"app with udp\"",
+		"set applications application app1 protocol udp",
+	}
+	if v := srv.Config(); !sameLines(v, expectConfig) {
+		t.Errorf("got unexpected config after update: %q, want %q", v, expectConfig)
+	}
+
+	// delete
+	deleteResp := resource.DeleteResponse{State: updateResp.State}
+	rsc.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, &deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf("got unexpected error on delete: %v", deleteResp.Diagnostics)
+	}
+	if v := srv.Config(); len(v) != 0 {
+		t.Errorf("got unexpected config after delete: %q", v)
+	}
+
+	// read after delete remove the resource from state
+	readDeletedResp := resource.ReadResponse{State: updateResp.State}
+	rsc.Read(ctx, resource.ReadRequest{State: updateResp.State}, &readDeletedResp)
+	if readDeletedResp.Diagnostics.HasError() {
+		t.Fatalf("got unexpected error on read: %v", readDeletedResp.Diagnostics)
+	}
+	if !readDeletedResp.State.Raw.IsNull() {
+		t.Errorf("expected resource removed from state after read of deleted application")
+	}
+
+	if v := len(srv.Commits()); v != 3 {
+		t.Errorf("got %d commits, want 3", v)
+	}
+}
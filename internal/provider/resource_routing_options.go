@@ -96,6 +96,8 @@ func (rsc *routingOptions) Schema(
 				Optional:    true,
 				Description: "Clean supported lines when destroy this resource.",
 			},
+			authoritativeAttrName:  authoritativeSchemaAttribute(rsc.junosName()),
+			unmanagedLinesAttrName: unmanagedLinesSchemaAttribute(rsc.junosName()),
 			"forwarding_table_export_configure_singly": schema.BoolAttribute{
 				Optional:    true,
 				Description: "Disable management of `forwarding-table export` in this resource.",
@@ -331,6 +333,8 @@ func (rsc *routingOptions) Schema(
 type routingOptionsData struct {
 	ID                                   types.String                         `tfsdk:"id"`
 	CleanOnDestroy                       types.Bool                           `tfsdk:"clean_on_destroy"`
+	Authoritative                        types.Bool                           `tfsdk:"authoritative"`
+	UnmanagedLines                       []types.String                       `tfsdk:"unmanaged_lines"`
 	ForwardingTableExportConfigureSingly types.Bool                           `tfsdk:"forwarding_table_export_configure_singly"`
 	InstanceExport                       []types.String                       `tfsdk:"instance_export"`
 	InstanceImport                       []types.String                       `tfsdk:"instance_import"`
@@ -345,6 +349,8 @@ type routingOptionsData struct {
 type routingOptionsConfig struct {
 	ID                                   types.String                              `tfsdk:"id"`
 	CleanOnDestroy                       types.Bool                                `tfsdk:"clean_on_destroy"`
+	Authoritative                        types.Bool                                `tfsdk:"authoritative"`
+	UnmanagedLines                       types.List                                `tfsdk:"unmanaged_lines"`
 	ForwardingTableExportConfigureSingly types.Bool                                `tfsdk:"forwarding_table_export_configure_singly"`
 	InstanceExport                       types.List                                `tfsdk:"instance_export"`
 	InstanceImport                       types.List                                `tfsdk:"instance_import"`
@@ -512,6 +518,9 @@ func (rsc *routingOptions) Read(
 		return
 	}
 
+	// needed to read the unmanaged lines
+	data.Authoritative = state.Authoritative
+
 	var _ resourceDataReadWithoutArg = &data
 	defaultResourceRead(
 		ctx,
@@ -606,6 +615,11 @@ func (rsc *routingOptions) Update(
 
 		return
 	}
+	if err := plan.delUnmanaged(ctx, junSess); err != nil {
+		resp.Diagnostics.AddError(tfdiag.ConfigDelErrSummary, err.Error())
+
+		return
+	}
 	if errPath, err := plan.set(ctx, junSess); err != nil {
 		if !errPath.Equal(path.Empty()) {
 			resp.Diagnostics.AddAttributeError(errPath, tfdiag.ConfigSetErrSummary, err.Error())
@@ -940,3 +954,43 @@ func (rscData *routingOptionsData) del(
 ) error {
 	return rscData.delOpts(ctx, rscData.ForwardingTableExportConfigureSingly.ValueBool(), junSess)
 }
+
+// dedicatedStatements return the statements in routing-options block managed by dedicated resources
+// (not removed with the authoritative argument).
+func (rscData *routingOptionsData) dedicatedStatements() []string {
+	statements := []string{
+		"aggregate",
+		"generate",
+		"rib",
+		"rib-groups",
+		"static",
+	}
+	if rscData.ForwardingTableExportConfigureSingly.ValueBool() {
+		statements = append(statements, "forwarding-table export")
+	}
+
+	return statements
+}
+
+func (rscData *routingOptionsData) readUnmanaged(
+	ctx context.Context, junSess *junos.Session,
+) (err error) {
+	if !rscData.Authoritative.ValueBool() {
+		return nil
+	}
+	rscData.UnmanagedLines, err = readUnmanagedLines(
+		ctx, junSess, "routing-options", rscData.dedicatedStatements(), rscData,
+	)
+
+	return err
+}
+
+func (rscData *routingOptionsData) delUnmanaged(
+	ctx context.Context, junSess *junos.Session,
+) error {
+	if !rscData.Authoritative.ValueBool() {
+		return nil
+	}
+
+	return delUnmanagedLines(ctx, junSess, "routing-options", rscData.dedicatedStatements(), rscData)
+}
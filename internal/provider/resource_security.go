@@ -97,6 +97,8 @@ func (rsc *security) Schema(
 				Optional:    true,
 				Description: "Clean supported lines when destroy this resource.",
 			},
+			authoritativeAttrName:  authoritativeSchemaAttribute(rsc.junosName()),
+			unmanagedLinesAttrName: unmanagedLinesSchemaAttribute(rsc.junosName()),
 		},
 		Blocks: map[string]schema.Block{
 			"alg": schema.SingleNestedBlock{
@@ -1288,6 +1290,8 @@ func (rsc *security) Schema(
 type securityData struct {
 	ID                           types.String                               `tfsdk:"id"`
 	CleanOnDestroy               types.Bool                                 `tfsdk:"clean_on_destroy"`
+	Authoritative                types.Bool                                 `tfsdk:"authoritative"`
+	UnmanagedLines               []types.String                             `tfsdk:"unmanaged_lines"`
 	Alg                          *securityBlockAlg                          `tfsdk:"alg"`
 	Flow                         *securityBlockFlow                         `tfsdk:"flow"`
 	ForwardingOptions            *securityBlockForwardingOptions            `tfsdk:"forwarding_options"`
@@ -1305,6 +1309,8 @@ type securityData struct {
 type securityConfig struct {
 	ID                           types.String                               `tfsdk:"id"`
 	CleanOnDestroy               types.Bool                                 `tfsdk:"clean_on_destroy"`
+	Authoritative                types.Bool                                 `tfsdk:"authoritative"`
+	UnmanagedLines               types.List                                 `tfsdk:"unmanaged_lines"`
 	Alg                          *securityBlockAlg                          `tfsdk:"alg"`
 	Flow                         *securityBlockFlow                         `tfsdk:"flow"`
 	ForwardingOptions            *securityBlockForwardingOptions            `tfsdk:"forwarding_options"`
@@ -2026,6 +2032,9 @@ func (rsc *security) Read(
 		return
 	}
 
+	// needed to read the unmanaged lines
+	data.Authoritative = state.Authoritative
+
 	var _ resourceDataReadWithoutArg = &data
 	defaultResourceRead(
 		ctx,
@@ -3643,3 +3652,58 @@ func (rscData *securityData) del(
 
 	return junSess.ConfigSet(ctx, configSet)
 }
+
+// dedicatedStatements return the statements in security block managed by dedicated resources
+// (not removed with the authoritative argument).
+func (rscData *securityData) dedicatedStatements() []string {
+	return []string{
+		"address-book",
+		"authentication-key-chains",
+		"dynamic-address",
+		"idp custom-attack",
+		"idp custom-attack-group",
+		"idp idp-policy",
+		"ike gateway",
+		"ike policy",
+		"ike proposal",
+		"ipsec policy",
+		"ipsec proposal",
+		"ipsec vpn",
+		"log stream",
+		"nat destination",
+		"nat source pool",
+		"nat source rule-set",
+		"nat static",
+		"policies from-zone",
+		"policies global",
+		"screen ids-option",
+		"screen white-list",
+		"utm custom-objects",
+		"utm feature-profile web-filtering juniper-enhanced profile",
+		"utm feature-profile web-filtering juniper-local profile",
+		"utm feature-profile web-filtering websense-redirect profile",
+		"utm utm-policy",
+		"zones security-zone",
+	}
+}
+
+func (rscData *securityData) readUnmanaged(
+	ctx context.Context, junSess *junos.Session,
+) (err error) {
+	if !rscData.Authoritative.ValueBool() {
+		return nil
+	}
+	rscData.UnmanagedLines, err = readUnmanagedLines(ctx, junSess, "security", rscData.dedicatedStatements(), rscData)
+
+	return err
+}
+
+func (rscData *securityData) delUnmanaged(
+	ctx context.Context, junSess *junos.Session,
+) error {
+	if !rscData.Authoritative.ValueBool() {
+		return nil
+	}
+
+	return delUnmanagedLines(ctx, junSess, "security", rscData.dedicatedStatements(), rscData)
+}
@@ -94,6 +94,8 @@ func (rsc *snmp) Schema(
 				Optional:    true,
 				Description: "Clean supported lines when destroy this resource.",
 			},
+			authoritativeAttrName:  authoritativeSchemaAttribute(rsc.junosName()),
+			unmanagedLinesAttrName: unmanagedLinesSchemaAttribute(rsc.junosName()),
 			"arp": schema.BoolAttribute{
 				Optional:    true,
 				Description: "JVision ARP.",
@@ -277,6 +279,8 @@ func (rsc *snmp) Schema(
 type snmpData struct {
 	ID                          types.String            `tfsdk:"id"`
 	CleanOnDestroy              types.Bool              `tfsdk:"clean_on_destroy"`
+	Authoritative               types.Bool              `tfsdk:"authoritative"`
+	UnmanagedLines              []types.String          `tfsdk:"unmanaged_lines"`
 	ARP                         types.Bool              `tfsdk:"arp"`
 	ARPHostNameResolution       types.Bool              `tfsdk:"arp_host_name_resolution"`
 	Contact                     types.String            `tfsdk:"contact"`
@@ -296,6 +300,8 @@ type snmpData struct {
 type snmpConfig struct {
 	ID                          types.String            `tfsdk:"id"`
 	CleanOnDestroy              types.Bool              `tfsdk:"clean_on_destroy"`
+	Authoritative               types.Bool              `tfsdk:"authoritative"`
+	UnmanagedLines              types.List              `tfsdk:"unmanaged_lines"`
 	ARP                         types.Bool              `tfsdk:"arp"`
 	ARPHostNameResolution       types.Bool              `tfsdk:"arp_host_name_resolution"`
 	Contact                     types.String            `tfsdk:"contact"`
@@ -405,6 +411,9 @@ func (rsc *snmp) Read(
 		return
 	}
 
+	// needed to read the unmanaged lines
+	data.Authoritative = state.Authoritative
+
 	var _ resourceDataReadWithoutArg = &data
 	defaultResourceRead(
 		ctx,
@@ -680,3 +689,37 @@ func (rscData *snmpData) del(
 
 	return junSess.ConfigSet(ctx, configSet)
 }
+
+// dedicatedStatements return the statements in snmp block managed by dedicated resources
+// (not removed with the authoritative argument).
+func (rscData *snmpData) dedicatedStatements() []string {
+	return []string{
+		"client-list",
+		"community",
+		"v3 snmp-community",
+		"v3 usm",
+		"v3 vacm",
+		"view",
+	}
+}
+
+func (rscData *snmpData) readUnmanaged(
+	ctx context.Context, junSess *junos.Session,
+) (err error) {
+	if !rscData.Authoritative.ValueBool() {
+		return nil
+	}
+	rscData.UnmanagedLines, err = readUnmanagedLines(ctx, junSess, "snmp", rscData.dedicatedStatements(), rscData)
+
+	return err
+}
+
+func (rscData *snmpData) delUnmanaged(
+	ctx context.Context, junSess *junos.Session,
+) error {
+	if !rscData.Authoritative.ValueBool() {
+		return nil
+	}
+
+	return delUnmanagedLines(ctx, junSess, "snmp", rscData.dedicatedStatements(), rscData)
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSnmpResourceAuthoritativeWithSimulator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	srv, client := newSimulatorClient(t,
+		"set snmp contact admin",
+		"set snmp location dc1",
+		"set snmp trap-options source-address lo0",
+		"set snmp community public authorization read-only",
+		"deactivate snmp contact",
+	)
+	rsc := newTestResource(ctx, newSnmpResource, client)
+
+	// create: the unmanaged lines are removed and the deactivated statement is activated
+	plan := rsc.newPlan(ctx, t, &snmpData{
+		Authoritative:  types.BoolValue(true),
+		UnmanagedLines: make([]types.String, 0),
+		Contact:        types.StringValue("admin"),
+	})
+	createResp := resource.CreateResponse{State: rsc.nullState(ctx)}
+	rsc.Create(ctx, resource.CreateRequest{
+		Config: planConfig(plan),
+		Plan:   plan,
+	}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("got unexpected error on create: %v", createResp.Diagnostics)
+	}
+	expectConfig := []string{
+		"set snmp contact admin",
+		"set snmp community public authorization read-only",
+	}
+	if v := srv.Config(); !sameLines(v, expectConfig) {
+		t.Errorf("got unexpected config after create: %q, want %q", v, expectConfig)
+	}
+
+	// read: the lines added outside of resource and not modeled by it are unmanaged lines
+	srv.LoadConfig(append(expectConfig, "set snmp location dc2", "set snmp trap-options source-address lo0"))
+	readResp := resource.ReadResponse{State: createResp.State}
+	rsc.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("got unexpected error on read: %v", readResp.Diagnostics)
+	}
+	var readData snmpData
+	readResp.State.Get(ctx, &readData)
+	if len(readData.UnmanagedLines) != 1 ||
+		readData.UnmanagedLines[0].ValueString() != "set snmp trap-options source-address lo0" ||
+		readData.Location.ValueString() != "dc2" {
+		t.Errorf("got unexpected state after read: %+v", readData)
+	}
+	if !readData.Authoritative.ValueBool() {
+		t.Errorf("got unexpected authoritative after read: %s", readData.Authoritative)
+	}
+
+	// read without authoritative
+	var state snmpData
+	createResp.State.Get(ctx, &state)
+	state.Authoritative = types.BoolNull()
+	state.UnmanagedLines = nil
+	stateNotAuthoritative := rsc.newState(ctx, t, &state)
+	readNotAuthoritativeResp := resource.ReadResponse{State: stateNotAuthoritative}
+	rsc.Read(ctx, resource.ReadRequest{State: stateNotAuthoritative}, &readNotAuthoritativeResp)
+	if readNotAuthoritativeResp.Diagnostics.HasError() {
+		t.Fatalf("got unexpected error on read: %v", readNotAuthoritativeResp.Diagnostics)
+	}
+	readNotAuthoritativeResp.State.Get(ctx, &readData)
+	if readData.UnmanagedLines != nil {
+		t.Errorf("got unexpected unmanaged lines without authoritative: %q", readData.UnmanagedLines)
+	}
+}
@@ -96,6 +96,8 @@ func (rsc *system) Schema(
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			authoritativeAttrName:  authoritativeSchemaAttribute(rsc.junosName()),
+			unmanagedLinesAttrName: unmanagedLinesSchemaAttribute(rsc.junosName()),
 			"authentication_order": schema.ListAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
@@ -1939,6 +1941,8 @@ func (rsc *system) Schema(
 //nolint:lll
 type systemData struct {
 	ID                                      types.String                      `tfsdk:"id"`
+	Authoritative                           types.Bool                        `tfsdk:"authoritative"`
+	UnmanagedLines                          []types.String                    `tfsdk:"unmanaged_lines"`
 	AuthenticationOrder                     []types.String                    `tfsdk:"authentication_order"`
 	AutoSnapshot                            types.Bool                        `tfsdk:"auto_snapshot"`
 	DefaultAddressSelection                 types.Bool                        `tfsdk:"default_address_selection"`
@@ -1982,6 +1986,8 @@ type systemData struct {
 //nolint:lll
 type systemConfig struct {
 	ID                                      types.String                            `tfsdk:"id"`
+	Authoritative                           types.Bool                              `tfsdk:"authoritative"`
+	UnmanagedLines                          types.List                              `tfsdk:"unmanaged_lines"`
 	AuthenticationOrder                     types.List                              `tfsdk:"authentication_order"`
 	AutoSnapshot                            types.Bool                              `tfsdk:"auto_snapshot"`
 	DefaultAddressSelection                 types.Bool                              `tfsdk:"default_address_selection"`
@@ -3137,6 +3143,9 @@ func (rsc *system) Read(
 		return
 	}
 
+	// needed to read the unmanaged lines
+	data.Authoritative = state.Authoritative
+
 	var _ resourceDataReadWithoutArg = &data
 	defaultResourceRead(
 		ctx,
@@ -5464,3 +5473,42 @@ func (rscData *systemData) del(
 
 	return junSess.ConfigSet(ctx, configSet)
 }
+
+// dedicatedStatements return the statements in system block managed by dedicated resources
+// (not removed with the authoritative argument).
+func (rscData *systemData) dedicatedStatements() []string {
+	return []string{
+		"login class",
+		"login user",
+		"ntp server",
+		"radius-server",
+		"root-authentication",
+		"services dhcp-local-server dhcpv6 group",
+		"services dhcp-local-server group",
+		"syslog file",
+		"syslog host",
+		"syslog user",
+		"tacplus-server",
+	}
+}
+
+func (rscData *systemData) readUnmanaged(
+	ctx context.Context, junSess *junos.Session,
+) (err error) {
+	if !rscData.Authoritative.ValueBool() {
+		return nil
+	}
+	rscData.UnmanagedLines, err = readUnmanagedLines(ctx, junSess, "system", rscData.dedicatedStatements(), rscData)
+
+	return err
+}
+
+func (rscData *systemData) delUnmanaged(
+	ctx context.Context, junSess *junos.Session,
+) error {
+	if !rscData.Authoritative.ValueBool() {
+		return nil
+	}
+
+	return delUnmanagedLines(ctx, junSess, "system", rscData.dedicatedStatements(), rscData)
+}
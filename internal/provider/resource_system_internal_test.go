@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSystemResourceAuthoritativeManagementAccessWithSimulator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	srv, client := newSimulatorClient(t,
+		"set system host-name router1",
+		"set system no-redirects",
+		"set system services ssh root-login deny",
+		"set system services netconf ssh port 830",
+	)
+	rsc := newTestResource(ctx, newSystemResource, client)
+
+	// create: the unmanaged lines are removed except the statements of access to the device
+	plan := rsc.newPlan(ctx, t, &systemData{
+		Authoritative:  types.BoolValue(true),
+		UnmanagedLines: make([]types.String, 0),
+		HostName:       types.StringValue("router1"),
+	})
+	createResp := resource.CreateResponse{State: rsc.nullState(ctx)}
+	rsc.Create(ctx, resource.CreateRequest{
+		Config: planConfig(plan),
+		Plan:   plan,
+	}, &createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf("got unexpected error on create: %v", createResp.Diagnostics)
+	}
+	expectConfig := []string{
+		"set system host-name router1",
+		"set system services ssh root-login deny",
+		"set system services netconf ssh port 830",
+	}
+	if v := srv.Config(); !sameLines(v, expectConfig) {
+		t.Errorf("got unexpected config after create: %q, want %q", v, expectConfig)
+	}
+
+	// read: the statements of access to the device are not unmanaged lines
+	readResp := resource.ReadResponse{State: createResp.State}
+	rsc.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("got unexpected error on read: %v", readResp.Diagnostics)
+	}
+	var readData systemData
+	readResp.State.Get(ctx, &readData)
+	if len(readData.UnmanagedLines) != 0 {
+		t.Errorf("got unexpected unmanaged lines after read: %q", readData.UnmanagedLines)
+	}
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+	"github.com/jeremmfr/terraform-provider-junos/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	authoritativeAttrName  = "authoritative"
+	unmanagedLinesAttrName = "unmanaged_lines"
+)
+
+// managementAccessStatements: the statements by block used by the provider to access the device
+// (never detected as unmanaged lines so as not to remove them and lose the access to the device).
+var managementAccessStatements = map[string][]string{
+	"system": {
+		"services netconf ssh",
+		"services ssh",
+	},
+}
+
+func authoritativeSchemaAttribute(junosName string) schema.BoolAttribute {
+	except := "the statements managed by dedicated resources"
+	if statements := managementAccessStatements[junosName]; len(statements) > 0 {
+		except += " and the statements of access to the device (`" + strings.Join(statements, "`, `") + "`)"
+	}
+
+	return schema.BoolAttribute{
+		Optional: true,
+		Description: "Own the whole `" + junosName + "` block:" +
+			" the lines in the block not managed by this resource" +
+			" (except " + except + ")" +
+			" are detected in `" + unmanagedLinesAttrName + "` when read and removed when apply.",
+	}
+}
+
+func unmanagedLinesSchemaAttribute(junosName string) schema.ListAttribute {
+	return schema.ListAttribute{
+		ElementType: types.StringType,
+		Computed:    true,
+		Description: "Lines in the `" + junosName + "` block not managed by this resource" +
+			" found on the device (only with `" + authoritativeAttrName + "`).",
+		PlanModifiers: []planmodifier.List{
+			unmanagedLinesPlanModifier{},
+		},
+	}
+}
+
+var _ planmodifier.List = unmanagedLinesPlanModifier{}
+
+// unmanagedLinesPlanModifier plan an empty list of unmanaged lines when the resource is authoritative
+// (to have a diff when unmanaged lines are found when read) and a null value otherwise.
+type unmanagedLinesPlanModifier struct{}
+
+func (m unmanagedLinesPlanModifier) Description(_ context.Context) string {
+	return "If `" + authoritativeAttrName + "` is true, modify plan to an empty list, otherwise to null."
+}
+
+func (m unmanagedLinesPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m unmanagedLinesPlanModifier) PlanModifyList(
+	ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse,
+) {
+	var authoritative types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(authoritativeAttrName), &authoritative)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case authoritative.IsUnknown():
+		resp.PlanValue = types.ListUnknown(types.StringType)
+	case authoritative.ValueBool():
+		resp.PlanValue = types.ListValueMust(types.StringType, []attr.Value{})
+	default:
+		resp.PlanValue = types.ListNull(types.StringType)
+	}
+}
+
+// readUnmanagedLines return the set (and deactivate) lines of the block of configuration
+// not generated by the set lines of data,
+// without the lines of statements managed by dedicated resources
+// and the lines of statements of access to the device.
+func readUnmanagedLines(
+	ctx context.Context, junSess *junos.Session, block string, dedicatedStatements []string, data resourceDataSet,
+) ([]types.String, error) {
+	showConfig, err := junSess.Command(ctx, junos.CmdShowConfig+block+junos.PipeDisplaySet)
+	if err != nil {
+		return nil, err
+	}
+
+	generatedLines := make([]string, 0)
+	captureSess := junos.NewClient("").NewLinesCaptureClient(func(lines []string) error {
+		generatedLines = append(generatedLines, lines...)
+
+		return nil
+	}).NewSessionWithoutNetconf(ctx)
+	if _, err := data.set(ctx, captureSess); err != nil {
+		return nil, fmt.Errorf("generating lines of resource: %w", err)
+	}
+
+	keptStatements := slices.Concat(dedicatedStatements, managementAccessStatements[block])
+	unmanagedLines := make([]types.String, 0)
+	for _, line := range configLinesNotCovered(configSetLines(showConfig), generatedLines) {
+		statement := strings.Join(utils.ConfigLineWords(line)[1:], " ") + " "
+		dedicated := false
+		for _, v := range keptStatements {
+			if strings.HasPrefix(statement, block+" "+v+" ") {
+				dedicated = true
+
+				break
+			}
+		}
+		if !dedicated {
+			unmanagedLines = append(unmanagedLines, types.StringValue(line))
+		}
+	}
+
+	return unmanagedLines, nil
+}
+
+// delUnmanagedLines delete the statements of the unmanaged lines
+// (set or deactivate lines) in the block of configuration.
+func delUnmanagedLines(
+	ctx context.Context, junSess *junos.Session, block string, dedicatedStatements []string, data resourceDataSet,
+) error {
+	unmanagedLines, err := readUnmanagedLines(ctx, junSess, block, dedicatedStatements, data)
+	if err != nil {
+		return err
+	}
+	if len(unmanagedLines) == 0 {
+		return nil
+	}
+
+	configSet := make([]string, len(unmanagedLines))
+	for i, line := range unmanagedLines {
+		configSet[i] = "delete " + strings.TrimPrefix(strings.TrimPrefix(line.ValueString(), "set "), "deactivate ")
+	}
+
+	return junSess.ConfigSet(ctx, configSet)
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/junos"
+	"github.com/jeremmfr/terraform-provider-junos/internal/junostest"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newSimulatorClient start a simulator of Junos device with the set lines of configuration
+// (stopped at the end of test) and return it with a client to connect to it.
+func newSimulatorClient(t *testing.T, config ...string) (*junostest.Server, *junos.Client) {
+	t.Helper()
+
+	srv := junostest.NewTestServer(t, config...)
+
+	return srv, junos.NewClient(srv.IP()).
+		WithPort(srv.Port()).
+		WithUserName("test").
+		WithPassword("test").
+		WithSleepShort(0).
+		WithSleepLock(0)
+}
+
+// testResource: resource configured with a client and its schema.
+type testResource struct {
+	resource.Resource
+
+	schema schema.Schema
+}
+
+func newTestResource(
+	ctx context.Context, newResource func() resource.Resource, client *junos.Client,
+) testResource {
+	rsc := newResource()
+	if rscWithConfigure, ok := rsc.(resource.ResourceWithConfigure); ok {
+		rscWithConfigure.Configure(ctx,
+			resource.ConfigureRequest{ProviderData: client}, &resource.ConfigureResponse{})
+	}
+	var schemaResp resource.SchemaResponse
+	rsc.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	return testResource{
+		Resource: rsc,
+		schema:   schemaResp.Schema,
+	}
+}
+
+// nullState return a state without resource.
+func (rsc testResource) nullState(ctx context.Context) tfsdk.State {
+	return tfsdk.State{Schema: rsc.schema, Raw: tftypes.NewValue(rsc.schema.Type().TerraformType(ctx), nil)}
+}
+
+// newState return a state with data of resource.
+func (rsc testResource) newState(ctx context.Context, t *testing.T, data any) tfsdk.State {
+	t.Helper()
+
+	state := rsc.nullState(ctx)
+	if diags := state.Set(ctx, data); diags.HasError() {
+		t.Fatalf("setting state: %v", diags)
+	}
+
+	return state
+}
+
+// newPlan return a plan with data of resource.
+func (rsc testResource) newPlan(ctx context.Context, t *testing.T, data any) tfsdk.Plan {
+	t.Helper()
+
+	return tfsdk.Plan(rsc.newState(ctx, t, data))
+}
+
+// planConfig return the config with the values of plan.
+func planConfig(plan tfsdk.Plan) tfsdk.Config {
+	return tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}
+}
+
+// testDataSource: data source configured with a client and its schema.
+type testDataSource struct {
+	datasource.DataSource
+
+	schema dsschema.Schema
+}
+
+func newTestDataSource(
+	ctx context.Context, dsc datasource.DataSource, client *junos.Client,
+) testDataSource {
+	if dscWithConfigure, ok := dsc.(datasource.DataSourceWithConfigure); ok {
+		dscWithConfigure.Configure(ctx,
+			datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+	}
+	var schemaResp datasource.SchemaResponse
+	dsc.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	return testDataSource{
+		DataSource: dsc,
+		schema:     schemaResp.Schema,
+	}
+}
+
+// read the data source with data in config.
+func (dsc testDataSource) read(ctx context.Context, t *testing.T, data any) datasource.ReadResponse {
+	t.Helper()
+
+	state := tfsdk.State{Schema: dsc.schema, Raw: tftypes.NewValue(dsc.schema.Type().TerraformType(ctx), nil)}
+	if diags := state.Set(ctx, data); diags.HasError() {
+		t.Fatalf("setting config: %v", diags)
+	}
+	readResp := datasource.ReadResponse{State: state}
+	dsc.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config(state)}, &readResp)
+
+	return readResp
+}
+
+// sameLines return true if the two lists have the same lines regardless of order.
+func sameLines(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+
+	return slices.Equal(a, b)
+}
@@ -0,0 +1,17 @@
+resource "terraform_data" "trigger" {
+  triggers_replace = "1"
+  lifecycle {
+    action_trigger {
+      events  = [before_create]
+      actions = [action.junos_commit_at.reboot]
+    }
+  }
+}
+
+action "junos_commit_at" "reboot" {
+  config {
+    action  = "set"
+    config  = "set applications application testacc-commit-at protocol tcp destination-port 22"
+    at_time = "reboot"
+  }
+}
@@ -0,0 +1,5 @@
+resource "terraform_data" "trigger" {
+  triggers_replace = "1"
+}
+
+data "junos_commit_at_pending" "testacc" {}
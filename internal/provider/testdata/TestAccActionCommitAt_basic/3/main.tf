@@ -0,0 +1,11 @@
+resource "terraform_data" "trigger" {
+  triggers_replace = "2"
+  lifecycle {
+    action_trigger {
+      events  = [before_create]
+      actions = [action.junos_commit_at_clear.clear]
+    }
+  }
+}
+
+action "junos_commit_at_clear" "clear" {}
@@ -0,0 +1,5 @@
+resource "terraform_data" "trigger" {
+  triggers_replace = "2"
+}
+
+data "junos_commit_at_pending" "testacc" {}
@@ -0,0 +1,5 @@
+resource "junos_application" "testacc_rollback" {
+  name             = "testacc_rollback"
+  protocol         = "tcp"
+  destination_port = "22"
+}
@@ -0,0 +1,14 @@
+resource "junos_application" "testacc_rollback" {
+  name             = "testacc_rollback"
+  protocol         = "tcp"
+  destination_port = "22"
+}
+
+data "junos_commit_history" "testacc" {
+  diff_rollback = 1
+  diff_compare  = 0
+}
+
+data "junos_commit_history" "testacc_filter" {
+  log_regex = "^create resource junos_application$"
+}
@@ -0,0 +1,15 @@
+resource "terraform_data" "trigger" {
+  triggers_replace = "1"
+  lifecycle {
+    action_trigger {
+      events  = [before_create]
+      actions = [action.junos_rollback.before_application]
+    }
+  }
+}
+
+action "junos_rollback" "before_application" {
+  config {
+    rollback_before_log = "^create resource junos_application$"
+  }
+}
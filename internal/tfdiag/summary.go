@@ -10,13 +10,16 @@ const (
 	MissingConfigErrSummary   = "Missing Configuration Error"
 	ConflictConfigErrSummary  = "Conflict Configuration Error"
 
-	ConfigLockErrSummary    = "Config Lock Error"
-	ConfigReadErrSummary    = "Config Read Error"
-	ConfigSetErrSummary     = "Config Set Error"
-	ConfigDelErrSummary     = "Config Del Error"
-	ConfigUnlockWarnSummary = "Config Unlock Warning"
-	ConfigCommitErrSummary  = "Config Commit Error"
-	ConfigCommitWarnSummary = "Config Commit Warning"
+	ConfigLockErrSummary     = "Config Lock Error"
+	ConfigReadErrSummary     = "Config Read Error"
+	ConfigSetErrSummary      = "Config Set Error"
+	ConfigDelErrSummary      = "Config Del Error"
+	ConfigUnlockWarnSummary  = "Config Unlock Warning"
+	ConfigDiscardWarnSummary = "Config Discard Warning"
+	ConfigCommitErrSummary   = "Config Commit Error"
+	ConfigCommitWarnSummary  = "Config Commit Warning"
+
+	PlanCommitCheckWarnSummary = "Plan Commit Check Warning"
 
 	NotFoundErrSummary  = "Not Found Error"
 	ReadErrSummary      = "Read Error"
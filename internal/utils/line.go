@@ -0,0 +1,44 @@
+package utils
+
+import "strings"
+
+// ConfigLineWords return the words of a line of configuration without the unnecessary quotes
+// around words like when the configuration is displayed by the device
+// (a quoted word without space or special character is displayed without quotes).
+func ConfigLineWords(line string) []string {
+	var (
+		words   []string
+		word    strings.Builder
+		quoted  bool
+		escaped bool
+	)
+	for _, r := range strings.TrimSpace(line) {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case !quoted && r == ' ':
+			if word.Len() > 0 {
+				words = append(words, word.String())
+				word.Reset()
+			}
+
+			continue
+		}
+		word.WriteRune(r)
+	}
+	if word.Len() > 0 {
+		words = append(words, word.String())
+	}
+	for i, w := range words {
+		if len(w) > 2 && strings.HasPrefix(w, `"`) && strings.HasSuffix(w, `"`) &&
+			!strings.ContainsAny(w[1:len(w)-1], " \t\"\\;{}#[]()<>&|'") {
+			words[i] = w[1 : len(w)-1]
+		}
+	}
+
+	return words
+}
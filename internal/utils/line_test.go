@@ -0,0 +1,54 @@
+package utils_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jeremmfr/terraform-provider-junos/internal/utils"
+)
+
+func TestConfigLineWords(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		val         string
+		expectValue []string
+	}
+	tests := map[string]testCase{
+		"empty": {
+			val:         "",
+			expectValue: nil,
+		},
+		"spaces": {
+			val:         "  set  system   host-name  r1 ",
+			expectValue: []string{"set", "system", "host-name", "r1"},
+		},
+		"unnecessary_quotes": {
+			val:         `set system host-name "r1"`,
+			expectValue: []string{"set", "system", "host-name", "r1"},
+		},
+		"quoted_space": {
+			val:         `set interfaces ge-0/0/0 description "to r2"`,
+			expectValue: []string{"set", "interfaces", "ge-0/0/0", "description", `"to r2"`},
+		},
+		"quoted_escaped": {
+			val:         `set interfaces ge-0/0/0 description "to \"r2 \\"`,
+			expectValue: []string{"set", "interfaces", "ge-0/0/0", "description", `"to \"r2 \\"`},
+		},
+		"quoted_special": {
+			val:         `set policy-options policy-statement "a;b"`,
+			expectValue: []string{"set", "policy-options", "policy-statement", `"a;b"`},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			r := utils.ConfigLineWords(test.val)
+
+			if !slices.Equal(r, test.expectValue) {
+				t.Fatalf("got unexpected value: want %q, got %q", test.expectValue, r)
+			}
+		})
+	}
+}